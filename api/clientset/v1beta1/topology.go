@@ -31,6 +31,7 @@ type TopologyInterface interface {
 	List(ctx context.Context, opts metav1.ListOptions) (*topologyv1.TopologyList, error)
 	Get(ctx context.Context, name string, options metav1.GetOptions) (*topologyv1.Topology, error)
 	Create(ctx context.Context, topology *topologyv1.Topology) (*topologyv1.Topology, error)
+	Update(ctx context.Context, topology *topologyv1.Topology) (*topologyv1.Topology, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 }
@@ -113,6 +114,20 @@ func (t *topologyClient) Create(ctx context.Context, topology *topologyv1.Topolo
 	return &result, err
 }
 
+func (t *topologyClient) Update(ctx context.Context, topology *topologyv1.Topology) (*topologyv1.Topology, error) {
+	result := topologyv1.Topology{}
+	err := t.restClient.
+		Put().
+		Namespace(t.ns).
+		Resource("topologies").
+		Name(topology.Name).
+		Body(topology).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
 func (t *topologyClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	opts.Watch = true
 	return t.restClient.
@@ -0,0 +1,88 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/h-fam/kne/topo"
+)
+
+var (
+	applyCmd = &cobra.Command{
+		Use:   "apply <topology file>",
+		Short: "Create or update Topology",
+		Long: `Create or update Topology.  Nodes are added or removed to match the
+topology file and only nodes whose links, config or definition changed have
+their pods recreated.  The post create steps of added and recreated nodes
+run once they are ready.`,
+		PreRunE:   validateTopology,
+		RunE:      applyFn,
+		ValidArgs: []string{"topology"},
+	}
+)
+
+func init() {
+	applyCmd.Flags().IntVar(&workers, "workers", topo.DefaultWorkers, "Number of nodes to create concurrently")
+	applyCmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum time to apply the topology and run the post create steps of created nodes (0 waits forever)")
+	rootCmd.AddCommand(applyCmd)
+}
+
+func applyFn(cmd *cobra.Command, args []string) error {
+	t, tpb, err := loadManager(cmd, args[0], topo.WithWorkers(workers))
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	r, err := t.Apply(ctx)
+	if err != nil {
+		return err
+	}
+	if err := t.ApplyImpairments(ctx, r.Unchanged); err != nil {
+		return fmt.Errorf("failed to apply link impairments: %w", err)
+	}
+	out := cmd.OutOrStdout()
+	if r.NewTopology {
+		fmt.Fprintf(out, "Topology %q created\n", tpb.Name)
+	} else {
+		fmt.Fprintf(out, "Topology %q updated\n", tpb.Name)
+	}
+	for _, s := range []struct {
+		desc  string
+		nodes []string
+	}{
+		{"Added", r.Added},
+		{"Removed", r.Removed},
+		{"Recreated", r.Recreated},
+		{"Unchanged", r.Unchanged},
+	} {
+		if len(s.nodes) != 0 {
+			fmt.Fprintf(out, "%s: %s\n", s.desc, strings.Join(s.nodes, ", "))
+		}
+	}
+	if err := t.PostCreate(ctx, r.Created()); err != nil {
+		return fmt.Errorf("post create failed: %w", err)
+	}
+	return nil
+}
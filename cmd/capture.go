@@ -0,0 +1,67 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	captureFile  string
+	captureCount uint32
+
+	captureCmd = &cobra.Command{
+		Use:   "capture <topology file> <node>:<intf> [-- <filter>]",
+		Short: "Capture packets on a topology interface",
+		Long: `Capture packets on a topology interface in pcap format.  Packets are
+written to stdout unless -w is given, so the output can be piped into
+wireshark:
+
+  kne_cli capture topo.pb.txt r1:eth1 | wireshark -k -i -`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: captureFn,
+	}
+)
+
+func init() {
+	captureCmd.Flags().StringVarP(&captureFile, "write", "w", "", "Write packets to file instead of stdout")
+	captureCmd.Flags().Uint32VarP(&captureCount, "count", "c", 0, "Stop after capturing count packets (0 captures until interrupted)")
+	rootCmd.AddCommand(captureCmd)
+}
+
+func captureFn(cmd *cobra.Command, args []string) error {
+	nodeName, intf, err := parseEndpoint(args[1])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	t, _, err := loadManager(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	var w io.Writer = cmd.OutOrStdout()
+	if captureFile != "" {
+		f, err := os.Create(captureFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return t.Capture(cmd.Context(), nodeName, intf, captureCount, args[2:], w, cmd.ErrOrStderr())
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Change the configuration of running nodes",
+	}
+	configPushCmd = &cobra.Command{
+		Use:   "push <topology file> <node> [config file]",
+		Short: "Replace the running config of a node (reads stdin without a config file)",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE:  configPushFn,
+	}
+	configGetCmd = &cobra.Command{
+		Use:   "get <topology file> <node> [output file]",
+		Short: "Get the running config of a node (writes stdout without an output file)",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE:  configGetFn,
+	}
+	configResetCmd = &cobra.Command{
+		Use:   "reset <topology file> <node>",
+		Short: "Restore the startup config of a node",
+		Args:  cobra.ExactArgs(2),
+		RunE:  configResetFn,
+	}
+)
+
+func init() {
+	configCmd.AddCommand(configPushCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configResetCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func configPushFn(cmd *cobra.Command, args []string) error {
+	t, _, err := loadManager(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	var r io.Reader = cmd.InOrStdin()
+	if len(args) == 3 {
+		f, err := os.Open(args[2])
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		defer f.Close()
+		r = f
+	}
+	if err := t.PushConfig(cmd.Context(), args[1], r); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Config of node %q replaced\n", args[1])
+	return nil
+}
+
+func configGetFn(cmd *cobra.Command, args []string) error {
+	t, _, err := loadManager(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+	if len(args) == 3 {
+		f, err := os.Create(args[2])
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := t.GetConfig(cmd.Context(), args[1], w); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	return nil
+}
+
+func configResetFn(cmd *cobra.Command, args []string) error {
+	t, _, err := loadManager(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	if err := t.ResetConfig(cmd.Context(), args[1]); err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Config of node %q reset\n", args[1])
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/h-fam/kne/topo"
+)
+
+var (
+	convertFormat string
+
+	convertCmd = &cobra.Command{
+		Use:   "convert <topology file> [output file]",
+		Short: "Convert a topology file between text, YAML and JSON",
+		Long: `Convert a topology file between text, YAML and JSON.  The input format
+is determined by the file extension.  The output format is taken from
+--format, or the extension of the output file if --format is not set.
+Without an output file the result is written to stdout.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: convertFn,
+	}
+)
+
+func init() {
+	convertCmd.Flags().StringVar(&convertFormat, "format", "", "Output format: text, yaml or json")
+	rootCmd.AddCommand(convertCmd)
+}
+
+func convertFn(cmd *cobra.Command, args []string) error {
+	tpb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	f := topo.FormatText
+	switch {
+	case convertFormat != "":
+		if f, err = topo.ParseFormat(convertFormat); err != nil {
+			return err
+		}
+	case len(args) == 2:
+		f = topo.FormatFromPath(args[1])
+	}
+	b, err := topo.Marshal(f, tpb)
+	if err != nil {
+		return err
+	}
+	if len(args) == 2 {
+		return ioutil.WriteFile(args[1], b, 0644)
+	}
+	_, err = cmd.OutOrStdout().Write(b)
+	return err
+}
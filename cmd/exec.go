@@ -0,0 +1,76 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	execCmd = &cobra.Command{
+		Use:   "exec <topology file> <node> -- <command> [args...]",
+		Short: "Execute a command on a node",
+		Long: `Execute a command on a node.  If stdin and stdout are a terminal the
+command is attached to it, otherwise stdin, stdout and stderr are passed
+through.  The exit status of the command is returned.`,
+		RunE: execFn,
+	}
+	consoleCmd = &cobra.Command{
+		Use:   "console <topology file> <node>",
+		Short: "Open the CLI of a node",
+		Long: `Open the CLI of a node in the local terminal.  The CLI is started with
+the entry command of the node's vendor.`,
+		Args: cobra.ExactArgs(2),
+		RunE: consoleFn,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+	rootCmd.AddCommand(consoleCmd)
+}
+
+func execFn(cmd *cobra.Command, args []string) error {
+	if cmd.ArgsLenAtDash() != 2 || len(args) < 3 {
+		return fmt.Errorf("%s: topology, node and command must be provided", cmd.Use)
+	}
+	t, _, err := loadManager(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	n, err := t.Node(args[1])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	command := args[2:]
+	if isTerminal() {
+		return runTerminal(cmd, n, command)
+	}
+	return n.Run(cmd.Context(), command, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+func consoleFn(cmd *cobra.Command, args []string) error {
+	t, _, err := loadManager(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	n, err := t.Node(args[1])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	return runTerminal(cmd, n, n.EntryCommand())
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/h-fam/kne/topo"
+)
+
+var (
+	graphFormat string
+	graphStatus bool
+
+	graphCmd = &cobra.Command{
+		Use:   "graph <topology file>",
+		Short: "Draw a diagram of a Topology",
+		Long: `Draw a diagram of the nodes and links of a Topology in DOT, Mermaid,
+JSON or SVG format.  SVG output requires the graphviz dot command.  With
+--status the nodes are colored by the status of their pods in the cluster.`,
+		PreRunE:   validateTopology,
+		RunE:      graphFn,
+		ValidArgs: []string{"topology"},
+	}
+)
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Output format: dot, mermaid, json or svg")
+	graphCmd.Flags().BoolVar(&graphStatus, "status", false, "Color nodes by the status of the running topology")
+	rootCmd.AddCommand(graphCmd)
+}
+
+func graphFn(cmd *cobra.Command, args []string) error {
+	tpb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	var status map[string]topo.NodeStatus
+	if graphStatus {
+		t, _, err := loadManager(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		if status, err = t.Status(cmd.Context()); err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+	}
+	g := topo.NewGraph(tpb, status)
+	out := cmd.OutOrStdout()
+	if graphFormat != "svg" {
+		return g.Write(out, topo.GraphFormat(graphFormat))
+	}
+	var dot bytes.Buffer
+	if err := g.Write(&dot, topo.GraphDOT); err != nil {
+		return err
+	}
+	c := exec.CommandContext(cmd.Context(), "dot", "-Tsvg")
+	c.Stdin = &dot
+	c.Stdout = out
+	c.Stderr = cmd.ErrOrStderr()
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("%s: failed to run graphviz dot: %w", cmd.Use, err)
+	}
+	return nil
+}
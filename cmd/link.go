@@ -0,0 +1,123 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+var (
+	impairment = struct {
+		delay       time.Duration
+		jitter      time.Duration
+		loss        float32
+		corruption  float32
+		duplication float32
+		rateKbps    uint64
+	}{}
+
+	linkCmd = &cobra.Command{
+		Use:   "link",
+		Short: "Change links of a running topology",
+	}
+	linkDownCmd = &cobra.Command{
+		Use:   "down <topology file> <node>:<intf>",
+		Short: "Set both ends of a link admin down",
+		Args:  cobra.ExactArgs(2),
+		RunE:  linkStateFn(false),
+	}
+	linkUpCmd = &cobra.Command{
+		Use:   "up <topology file> <node>:<intf>",
+		Short: "Set both ends of a link admin up",
+		Args:  cobra.ExactArgs(2),
+		RunE:  linkStateFn(true),
+	}
+	linkSetCmd = &cobra.Command{
+		Use:   "set <topology file> <node>:<intf>",
+		Short: "Replace the impairment on both ends of a link (no flags clears it)",
+		Args:  cobra.ExactArgs(2),
+		RunE:  linkSetFn,
+	}
+)
+
+func init() {
+	linkSetCmd.Flags().DurationVar(&impairment.delay, "delay", 0, "Added latency, e.g. 50ms")
+	linkSetCmd.Flags().DurationVar(&impairment.jitter, "jitter", 0, "Latency variation, e.g. 5ms (requires --delay)")
+	linkSetCmd.Flags().Float32Var(&impairment.loss, "loss", 0, "Packet loss percentage")
+	linkSetCmd.Flags().Float32Var(&impairment.corruption, "corruption", 0, "Packet corruption percentage")
+	linkSetCmd.Flags().Float32Var(&impairment.duplication, "duplication", 0, "Packet duplication percentage")
+	linkSetCmd.Flags().Uint64Var(&impairment.rateKbps, "rate", 0, "Bandwidth cap in kbit/s")
+	linkCmd.AddCommand(linkDownCmd)
+	linkCmd.AddCommand(linkUpCmd)
+	linkCmd.AddCommand(linkSetCmd)
+	rootCmd.AddCommand(linkCmd)
+}
+
+// parseEndpoint splits a <node>:<intf> argument.
+func parseEndpoint(s string) (string, string, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid endpoint %q: must be <node>:<intf>", s)
+	}
+	return parts[0], parts[1], nil
+}
+
+func linkStateFn(up bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		nodeName, intf, err := parseEndpoint(args[1])
+		if err != nil {
+			return fmt.Errorf("%s: %w", cmd.Use, err)
+		}
+		t, _, err := loadManager(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		if err := t.SetLinkState(cmd.Context(), nodeName, intf, up); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Link %s:%s %s\n", nodeName, intf, cmd.Name())
+		return nil
+	}
+}
+
+func linkSetFn(cmd *cobra.Command, args []string) error {
+	nodeName, intf, err := parseEndpoint(args[1])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	imp := &topopb.Impairment{
+		DelayMs:     uint32(impairment.delay / time.Millisecond),
+		JitterMs:    uint32(impairment.jitter / time.Millisecond),
+		Loss:        impairment.loss,
+		Corruption:  impairment.corruption,
+		Duplication: impairment.duplication,
+		RateKbps:    impairment.rateKbps,
+	}
+	t, _, err := loadManager(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	if err := t.SetLinkImpairment(cmd.Context(), nodeName, intf, imp); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Link %s:%s impairment set: %v\n", nodeName, intf, imp)
+	return nil
+}
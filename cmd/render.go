@@ -0,0 +1,95 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/yaml"
+
+	"github.com/h-fam/kne/topo"
+)
+
+var (
+	renderDir string
+
+	renderCmd = &cobra.Command{
+		Use:   "render <topology file>",
+		Short: "Render the Kubernetes manifests of a Topology",
+		Long: `Render the Kubernetes manifests of a Topology without contacting the
+cluster.  The Namespace, meshnet Topology resources, ConfigMaps, Services and
+Pods that create would push are written as YAML to stdout, or with -o as one
+file per object to a directory.  Files are numbered in the order the objects
+must be created.`,
+		PreRunE:   validateTopology,
+		RunE:      renderFn,
+		ValidArgs: []string{"topology"},
+	}
+)
+
+func init() {
+	renderCmd.Flags().StringVarP(&renderDir, "output", "o", "", "Directory to write one manifest per object to")
+	rootCmd.AddCommand(renderCmd)
+}
+
+func renderFn(cmd *cobra.Command, args []string) error {
+	tpb, err := topo.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	objs, err := topo.Render(tpb)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if renderDir != "" {
+		if err := os.MkdirAll(renderDir, 0755); err != nil {
+			return err
+		}
+	}
+	var out bytes.Buffer
+	for i, obj := range objs {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if renderDir == "" {
+			out.WriteString("---\n")
+			out.Write(b)
+			continue
+		}
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		fName := filepath.Join(renderDir, fmt.Sprintf("%03d-%s-%s.yaml", i, kind, m.GetName()))
+		if err := ioutil.WriteFile(fName, b, 0644); err != nil {
+			return err
+		}
+	}
+	if renderDir == "" {
+		_, err = cmd.OutOrStdout().Write(out.Bytes())
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d manifests to %s\n", len(objs), renderDir)
+	return nil
+}
@@ -16,13 +16,19 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/exec"
 	"k8s.io/client-go/util/homedir"
 )
 
@@ -31,6 +37,10 @@ var (
 	kubecfg        string
 	topofile       string
 	dryrun         bool
+	wait           bool
+	timeout        time.Duration
+	keepOnFailure  bool
+	workers        int
 
 	rootCmd = &cobra.Command{
 		Use:   "kne_cli",
@@ -47,6 +57,16 @@ func ExecuteContext(ctx context.Context) error {
 	return rootCmd.ExecuteContext(ctx)
 }
 
+// ExitCode returns the process exit code for err returned by
+// ExecuteContext.  Commands run on nodes pass through their exit status.
+func ExitCode(err error) int {
+	var ee exec.ExitError
+	if errors.As(err, &ee) {
+		return ee.ExitStatus()
+	}
+	return 1
+}
+
 func init() {
 	if home := homedir.HomeDir(); home != "" {
 		defaultKubeCfg = filepath.Join(home, ".kube", "config")
@@ -54,11 +74,14 @@ func init() {
 	rootCmd.SetOut(os.Stdout)
 	rootCmd.PersistentFlags().StringVar(&kubecfg, "kubecfg", defaultKubeCfg, "kubeconfig file")
 	createCmd.Flags().BoolVar(&dryrun, "dryrun", false, "Generate topology but do not push to k8s")
+	createCmd.Flags().BoolVar(&wait, "wait", false, "Wait for all nodes to be running and ready")
+	createCmd.Flags().BoolVar(&keepOnFailure, "keep-on-failure", false, "Keep created objects if creating the topology fails")
+	createCmd.Flags().IntVar(&workers, "workers", topo.DefaultWorkers, "Number of nodes to create concurrently")
+	deleteCmd.Flags().IntVar(&workers, "workers", topo.DefaultWorkers, "Number of nodes to delete concurrently")
+	createCmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum time to create the topology and wait for nodes to be ready (0 waits forever)")
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(showCmd)
-	//rootCmd.AddCommand(graphCmd)
-
 }
 
 var (
@@ -92,12 +115,28 @@ func validateTopology(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// loadManager loads the topology in fName and returns a manager for it.
+func loadManager(cmd *cobra.Command, fName string, opts ...topo.Option) (*topo.Manager, *topopb.Topology, error) {
+	tpb, err := topo.Load(fName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	t, err := topo.New(kubecfg, tpb, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	if err := t.Load(cmd.Context()); err != nil {
+		return nil, nil, fmt.Errorf("failed to load topology: %w", err)
+	}
+	return t, tpb, nil
+}
+
 func createFn(cmd *cobra.Command, args []string) error {
 	topopb, err := topo.Load(args[0])
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
-	t, err := topo.New(kubecfg, topopb)
+	t, err := topo.New(kubecfg, topopb, topo.WithKeepOnFailure(keepOnFailure), topo.WithWorkers(workers))
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
@@ -109,11 +148,32 @@ func createFn(cmd *cobra.Command, args []string) error {
 	if dryrun {
 		return nil
 	}
-	if err := t.Push(cmd.Context()); err != nil {
+	ctx := cmd.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := t.Push(ctx); err != nil {
 		return err
 	}
-	fmt.Fprintf(out, "Topology %q created", topopb.Name)
+	fmt.Fprintf(out, "Topology %q created\n", topopb.Name)
+	fmt.Fprintf(out, "Applying link impairments and running post create steps\n")
+	if err := t.PostCreate(ctx, t.NodeNames()); err != nil {
+		return fmt.Errorf("topology %q created but post create failed: %w", topopb.Name, err)
+	}
+	if wait {
+		fmt.Fprintf(out, "Waiting for nodes to be ready\n")
+		if err := t.WaitReady(ctx, func(s topo.NodeStatus) {
+			fmt.Fprintf(out, "Node %s\n", s)
+		}); err != nil {
+			return err
+		}
+	}
 	r, err := t.Resources(cmd.Context())
+	if err != nil {
+		return err
+	}
 	fmt.Fprintf(out, "Pods:\n")
 	for _, p := range r.Pods {
 		fmt.Fprintf(out, "%s\n", p.Name)
@@ -127,7 +187,7 @@ func deleteFn(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
-	t, err := topo.New(kubecfg, topopb)
+	t, err := topo.New(kubecfg, topopb, topo.WithWorkers(workers))
 	if err != nil {
 		return fmt.Errorf("%s: %w", cmd.Use, err)
 	}
@@ -167,5 +227,38 @@ func showFn(cmd *cobra.Command, args []string) error {
 	for _, p := range r.Pods {
 		fmt.Fprintf(out, "%s:%s IP:%s\n", topopb.Name, p.Name, p.Status.PodIP)
 	}
+	showAddresses(out, topopb)
 	return nil
 }
+
+// showAddresses writes the loopback and link addresses of the loaded
+// topology t, if any are set.
+func showAddresses(w io.Writer, t *topopb.Topology) {
+	join := func(addrs ...string) string {
+		var s []string
+		for _, a := range addrs {
+			if a != "" {
+				s = append(s, a)
+			}
+		}
+		return strings.Join(s, ",")
+	}
+	var loopbacks, links []string
+	for _, n := range t.Nodes {
+		if a := join(n.LoopbackIpv4, n.LoopbackIpv6); a != "" {
+			loopbacks = append(loopbacks, fmt.Sprintf("%s %s", n.Name, a))
+		}
+	}
+	for _, l := range t.Links {
+		a, z := join(l.AIpv4, l.AIpv6), join(l.ZIpv4, l.ZIpv6)
+		if a != "" || z != "" {
+			links = append(links, fmt.Sprintf("%s:%s %s - %s:%s %s", l.ANode, l.AInt, a, l.ZNode, l.ZInt, z))
+		}
+	}
+	if len(loopbacks) != 0 {
+		fmt.Fprintf(w, "Loopbacks:\n%s\n", strings.Join(loopbacks, "\n"))
+	}
+	if len(links) != 0 {
+		fmt.Fprintf(w, "Links:\n%s\n", strings.Join(links, "\n"))
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo"
+)
+
+var (
+	runTypes     []string
+	runLabels    map[string]string
+	runOutputDir string
+
+	runCmd = &cobra.Command{
+		Use:   "run <topology file> -- <command> [args...]",
+		Short: "Run a command on many nodes",
+		Long: `Run a command concurrently on all nodes of a topology, or the nodes
+selected by --type and --label.  The output of each node is printed grouped by
+node, or with --output-dir written to <node>.out and <node>.err files.  The
+command fails if the command failed on any node.`,
+		RunE: runFn,
+	}
+)
+
+func init() {
+	runCmd.Flags().StringSliceVar(&runTypes, "type", nil, "Only run on nodes of these types, e.g. AristaCEOS")
+	runCmd.Flags().StringToStringVar(&runLabels, "label", nil, "Only run on nodes with these labels, as key=value")
+	runCmd.Flags().StringVar(&runOutputDir, "output-dir", "", "Directory to write the output of each node to")
+	runCmd.Flags().IntVar(&workers, "workers", topo.DefaultWorkers, "Number of nodes to run the command on concurrently")
+	rootCmd.AddCommand(runCmd)
+}
+
+func runFn(cmd *cobra.Command, args []string) error {
+	if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+		return fmt.Errorf("%s: topology and command must be provided", cmd.Use)
+	}
+	f := topo.NodeFilter{Labels: runLabels}
+	for _, t := range runTypes {
+		v, ok := topopb.Node_Type_value[t]
+		if !ok {
+			return fmt.Errorf("%s: unknown node type %q", cmd.Use, t)
+		}
+		f.Types = append(f.Types, topopb.Node_Type(v))
+	}
+	t, _, err := loadManager(cmd, args[0], topo.WithWorkers(workers))
+	if err != nil {
+		return err
+	}
+	results := t.RunCommand(cmd.Context(), f, args[1:])
+	if len(results) == 0 {
+		return fmt.Errorf("%s: no nodes selected", cmd.Use)
+	}
+	if runOutputDir != "" {
+		if err := os.MkdirAll(runOutputDir, 0755); err != nil {
+			return err
+		}
+	}
+	out := cmd.OutOrStdout()
+	var failed []string
+	for _, r := range results {
+		status := "ok"
+		if r.Err != nil {
+			failed = append(failed, r.Node)
+			status = fmt.Sprintf("failed: %v", r.Err)
+		}
+		if runOutputDir != "" {
+			if err := ioutil.WriteFile(filepath.Join(runOutputDir, r.Node+".out"), r.Stdout, 0644); err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(runOutputDir, r.Node+".err"), r.Stderr, 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s: %s\n", r.Node, status)
+			continue
+		}
+		fmt.Fprintf(out, "=== %s (%s) ===\n", r.Node, status)
+		b := append(append([]byte{}, r.Stdout...), r.Stderr...)
+		if len(b) != 0 && b[len(b)-1] != '\n' {
+			b = append(b, '\n')
+		}
+		out.Write(b)
+	}
+	if len(failed) != 0 {
+		return fmt.Errorf("%s: command failed on %d of %d nodes: %s", cmd.Use, len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/h-fam/kne/topo/node"
+)
+
+// terminalSizes is a remotecommand.TerminalSizeQueue reporting the size of
+// the local terminal initially and whenever it changes.
+type terminalSizes struct {
+	fd      int
+	resized chan os.Signal
+	done    chan struct{}
+	started bool
+}
+
+func newTerminalSizes(fd int) *terminalSizes {
+	t := &terminalSizes{
+		fd:      fd,
+		resized: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+	notifyResize(t.resized)
+	return t
+}
+
+// Next blocks until the terminal size changes and returns the new size.  It
+// returns nil once stop is called.
+func (t *terminalSizes) Next() *remotecommand.TerminalSize {
+	if t.started {
+		select {
+		case <-t.resized:
+		case <-t.done:
+			return nil
+		}
+	}
+	t.started = true
+	w, h, err := terminal.GetSize(t.fd)
+	if err != nil {
+		return nil
+	}
+	return &remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)}
+}
+
+func (t *terminalSizes) stop() {
+	signal.Stop(t.resized)
+	close(t.done)
+}
+
+// isTerminal reports whether both stdin and stdout are terminals.
+func isTerminal() bool {
+	return terminal.IsTerminal(int(os.Stdin.Fd())) && terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// runTerminal runs command on n attached to the local terminal, which is put
+// in raw mode for the duration of the command.
+func runTerminal(cmd *cobra.Command, n *node.Node, command []string) error {
+	if !isTerminal() {
+		return fmt.Errorf("%s: stdin and stdout must be a terminal", cmd.Use)
+	}
+	fd := int(os.Stdin.Fd())
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("%s: failed to set terminal to raw mode: %w", cmd.Use, err)
+	}
+	defer terminal.Restore(fd, state)
+	sizes := newTerminalSizes(fd)
+	defer sizes.stop()
+	return n.Terminal(cmd.Context(), command, os.Stdin, os.Stdout, sizes)
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/h-fam/kne/topo"
+)
+
+var (
+	validateCmd = &cobra.Command{
+		Use:       "validate <topology file>",
+		Short:     "Validate Topology without contacting the cluster",
+		PreRunE:   validateTopology,
+		RunE:      validateFn,
+		ValidArgs: []string{"topology"},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func validateFn(cmd *cobra.Command, args []string) error {
+	errs, err := topo.ValidateFile(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Use, err)
+	}
+	out := cmd.OutOrStdout()
+	for _, e := range errs {
+		fmt.Fprintln(out, e)
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%s: %d errors found", args[0], len(errs))
+	}
+	fmt.Fprintf(out, "%s: topology is valid\n", args[0])
+	return nil
+}
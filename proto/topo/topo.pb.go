@@ -21,7 +21,11 @@
 package topo
 
 import (
+	context "context"
 	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -51,20 +55,26 @@ const (
 	Node_FRR         Node_Type = 6
 	Node_JuniperVMX  Node_Type = 7
 	Node_CiscoCSR    Node_Type = 8
+	Node_NokiaSRL    Node_Type = 9
+	Node_SonicVS     Node_Type = 10
+	Node_OTG         Node_Type = 11
 )
 
 // Enum value maps for Node_Type.
 var (
 	Node_Type_name = map[int32]string{
-		0: "Unknown",
-		1: "Host",
-		2: "AristaCEOS",
-		3: "JuniperCEVO",
-		4: "CiscoCXR",
-		5: "Quagga",
-		6: "FRR",
-		7: "JuniperVMX",
-		8: "CiscoCSR",
+		0:  "Unknown",
+		1:  "Host",
+		2:  "AristaCEOS",
+		3:  "JuniperCEVO",
+		4:  "CiscoCXR",
+		5:  "Quagga",
+		6:  "FRR",
+		7:  "JuniperVMX",
+		8:  "CiscoCSR",
+		9:  "NokiaSRL",
+		10: "SonicVS",
+		11: "OTG",
 	}
 	Node_Type_value = map[string]int32{
 		"Unknown":     0,
@@ -76,6 +86,9 @@ var (
 		"FRR":         6,
 		"JuniperVMX":  7,
 		"CiscoCSR":    8,
+		"NokiaSRL":    9,
+		"SonicVS":     10,
+		"OTG":         11,
 	}
 )
 
@@ -103,7 +116,7 @@ func (x Node_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Node_Type.Descriptor instead.
 func (Node_Type) EnumDescriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{1, 0}
+	return file_topo_proto_rawDescGZIP(), []int{2, 0}
 }
 
 // Topology message defines what nodes and links will be created inside the mesh.
@@ -112,9 +125,10 @@ type Topology struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // Name of the topology - will be linked to the cluster name
-	Nodes []*Node `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"` // List of nodes in the topology
-	Links []*Link `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"` // connections between Nodes.
+	Name       string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`             // Name of the topology - will be linked to the cluster name
+	Nodes      []*Node     `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`           // List of nodes in the topology
+	Links      []*Link     `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`           // connections between Nodes.
+	Addressing *Addressing `protobuf:"bytes,4,opt,name=addressing,proto3" json:"addressing,omitempty"` // Pools link and loopback addresses are assigned from.
 }
 
 func (x *Topology) Reset() {
@@ -170,24 +184,109 @@ func (x *Topology) GetLinks() []*Link {
 	return nil
 }
 
+func (x *Topology) GetAddressing() *Addressing {
+	if x != nil {
+		return x.Addressing
+	}
+	return nil
+}
+
+// Addressing defines the pools addresses are assigned from when the topology
+// is loaded.  Each link without addresses is assigned the next /31 (or /127)
+// of the link pool and each node without a loopback the next address of the
+// loopback pool, in the order they appear in the topology.  Explicitly set
+// addresses are kept and never assigned to other links or nodes.
+type Addressing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LinkIpv4     string `protobuf:"bytes,1,opt,name=link_ipv4,json=linkIpv4,proto3" json:"link_ipv4,omitempty"`             // IPv4 pool for links, e.g. 10.0.0.0/16.
+	LinkIpv6     string `protobuf:"bytes,2,opt,name=link_ipv6,json=linkIpv6,proto3" json:"link_ipv6,omitempty"`             // IPv6 pool for links, e.g. 2001:db8::/64.
+	LoopbackIpv4 string `protobuf:"bytes,3,opt,name=loopback_ipv4,json=loopbackIpv4,proto3" json:"loopback_ipv4,omitempty"` // IPv4 pool for loopbacks, e.g. 10.255.0.0/24.
+	LoopbackIpv6 string `protobuf:"bytes,4,opt,name=loopback_ipv6,json=loopbackIpv6,proto3" json:"loopback_ipv6,omitempty"` // IPv6 pool for loopbacks, e.g. 2001:db8:ffff::/64.
+}
+
+func (x *Addressing) Reset() {
+	*x = Addressing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Addressing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Addressing) ProtoMessage() {}
+
+func (x *Addressing) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Addressing.ProtoReflect.Descriptor instead.
+func (*Addressing) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Addressing) GetLinkIpv4() string {
+	if x != nil {
+		return x.LinkIpv4
+	}
+	return ""
+}
+
+func (x *Addressing) GetLinkIpv6() string {
+	if x != nil {
+		return x.LinkIpv6
+	}
+	return ""
+}
+
+func (x *Addressing) GetLoopbackIpv4() string {
+	if x != nil {
+		return x.LoopbackIpv4
+	}
+	return ""
+}
+
+func (x *Addressing) GetLoopbackIpv6() string {
+	if x != nil {
+		return x.LoopbackIpv6
+	}
+	return ""
+}
+
 // Node is a single container inside the topology
 type Node struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                                       // Name of the node in the topology. Must be unique.
-	Type        Node_Type           `protobuf:"varint,2,opt,name=type,proto3,enum=topo.Node_Type" json:"type,omitempty"`                                                                                  // Type of node to create.
-	Labels      map[string]string   `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`           // Metadata labels describing the node.
-	Config      *Config             `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`                                                                                                   // Pod specfic configuration of the node.
-	Services    map[uint32]*Service `protobuf:"bytes,6,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`      // Map of services to enable on the node.
-	Constraints map[string]string   `protobuf:"bytes,7,rep,name=constraints,proto3" json:"constraints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Any k8s constraints required by node.
+	Name         string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                                       // Name of the node in the topology. Must be unique.
+	Type         Node_Type           `protobuf:"varint,2,opt,name=type,proto3,enum=topo.Node_Type" json:"type,omitempty"`                                                                                  // Type of node to create.
+	Labels       map[string]string   `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`           // Metadata labels describing the node.
+	Config       *Config             `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`                                                                                                   // Pod specfic configuration of the node.
+	Services     map[uint32]*Service `protobuf:"bytes,6,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`      // Map of services to enable on the node.
+	Constraints  map[string]string   `protobuf:"bytes,7,rep,name=constraints,proto3" json:"constraints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Any k8s constraints required by node.
+	LoopbackIpv4 string              `protobuf:"bytes,8,opt,name=loopback_ipv4,json=loopbackIpv4,proto3" json:"loopback_ipv4,omitempty"`                                                                   // IPv4 loopback address in CIDR notation.
+	LoopbackIpv6 string              `protobuf:"bytes,9,opt,name=loopback_ipv6,json=loopbackIpv6,proto3" json:"loopback_ipv6,omitempty"`                                                                   // IPv6 loopback address in CIDR notation.
 }
 
 func (x *Node) Reset() {
 	*x = Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[1]
+		mi := &file_topo_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -200,7 +299,7 @@ func (x *Node) String() string {
 func (*Node) ProtoMessage() {}
 
 func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[1]
+	mi := &file_topo_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -213,7 +312,7 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Node.ProtoReflect.Descriptor instead.
 func (*Node) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{1}
+	return file_topo_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Node) GetName() string {
@@ -258,6 +357,20 @@ func (x *Node) GetConstraints() map[string]string {
 	return nil
 }
 
+func (x *Node) GetLoopbackIpv4() string {
+	if x != nil {
+		return x.LoopbackIpv4
+	}
+	return ""
+}
+
+func (x *Node) GetLoopbackIpv6() string {
+	if x != nil {
+		return x.LoopbackIpv6
+	}
+	return ""
+}
+
 // Link is single link between nodes in the topology.
 // Interfaces must start eth1 - eth0 is the default k8s interface.
 type Link struct {
@@ -265,16 +378,21 @@ type Link struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ANode string `protobuf:"bytes,1,opt,name=a_node,json=aNode,proto3" json:"a_node,omitempty"`
-	AInt  string `protobuf:"bytes,2,opt,name=a_int,json=aInt,proto3" json:"a_int,omitempty"`
-	ZNode string `protobuf:"bytes,3,opt,name=z_node,json=zNode,proto3" json:"z_node,omitempty"`
-	ZInt  string `protobuf:"bytes,4,opt,name=z_int,json=zInt,proto3" json:"z_int,omitempty"`
+	ANode      string      `protobuf:"bytes,1,opt,name=a_node,json=aNode,proto3" json:"a_node,omitempty"`
+	AInt       string      `protobuf:"bytes,2,opt,name=a_int,json=aInt,proto3" json:"a_int,omitempty"`
+	ZNode      string      `protobuf:"bytes,3,opt,name=z_node,json=zNode,proto3" json:"z_node,omitempty"`
+	ZInt       string      `protobuf:"bytes,4,opt,name=z_int,json=zInt,proto3" json:"z_int,omitempty"`
+	Impairment *Impairment `protobuf:"bytes,5,opt,name=impairment,proto3" json:"impairment,omitempty"`    // Impairments applied to both ends of the link.
+	AIpv4      string      `protobuf:"bytes,6,opt,name=a_ipv4,json=aIpv4,proto3" json:"a_ipv4,omitempty"` // IPv4 address of the A end in CIDR notation, e.g. 10.0.0.0/31.
+	ZIpv4      string      `protobuf:"bytes,7,opt,name=z_ipv4,json=zIpv4,proto3" json:"z_ipv4,omitempty"` // IPv4 address of the Z end in CIDR notation.
+	AIpv6      string      `protobuf:"bytes,8,opt,name=a_ipv6,json=aIpv6,proto3" json:"a_ipv6,omitempty"` // IPv6 address of the A end in CIDR notation.
+	ZIpv6      string      `protobuf:"bytes,9,opt,name=z_ipv6,json=zIpv6,proto3" json:"z_ipv6,omitempty"` // IPv6 address of the Z end in CIDR notation.
 }
 
 func (x *Link) Reset() {
 	*x = Link{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[2]
+		mi := &file_topo_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -287,7 +405,7 @@ func (x *Link) String() string {
 func (*Link) ProtoMessage() {}
 
 func (x *Link) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[2]
+	mi := &file_topo_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +418,7 @@ func (x *Link) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Link.ProtoReflect.Descriptor instead.
 func (*Link) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{2}
+	return file_topo_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Link) GetANode() string {
@@ -331,6 +449,130 @@ func (x *Link) GetZInt() string {
 	return ""
 }
 
+func (x *Link) GetImpairment() *Impairment {
+	if x != nil {
+		return x.Impairment
+	}
+	return nil
+}
+
+func (x *Link) GetAIpv4() string {
+	if x != nil {
+		return x.AIpv4
+	}
+	return ""
+}
+
+func (x *Link) GetZIpv4() string {
+	if x != nil {
+		return x.ZIpv4
+	}
+	return ""
+}
+
+func (x *Link) GetAIpv6() string {
+	if x != nil {
+		return x.AIpv6
+	}
+	return ""
+}
+
+func (x *Link) GetZIpv6() string {
+	if x != nil {
+		return x.ZIpv6
+	}
+	return ""
+}
+
+// Impairment is a set of netem impairments applied to a link.  They are
+// applied on egress of both ends so each direction is impaired independently.
+type Impairment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DelayMs     uint32  `protobuf:"varint,1,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`    // Added latency in milliseconds.
+	JitterMs    uint32  `protobuf:"varint,2,opt,name=jitter_ms,json=jitterMs,proto3" json:"jitter_ms,omitempty"` // Latency variation in milliseconds (requires delay).
+	Loss        float32 `protobuf:"fixed32,3,opt,name=loss,proto3" json:"loss,omitempty"`                        // Packet loss percentage (0-100).
+	Corruption  float32 `protobuf:"fixed32,4,opt,name=corruption,proto3" json:"corruption,omitempty"`            // Packet corruption percentage (0-100).
+	Duplication float32 `protobuf:"fixed32,5,opt,name=duplication,proto3" json:"duplication,omitempty"`          // Packet duplication percentage (0-100).
+	RateKbps    uint64  `protobuf:"varint,6,opt,name=rate_kbps,json=rateKbps,proto3" json:"rate_kbps,omitempty"` // Bandwidth cap in kbit/s (0 = unlimited).
+}
+
+func (x *Impairment) Reset() {
+	*x = Impairment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Impairment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Impairment) ProtoMessage() {}
+
+func (x *Impairment) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Impairment.ProtoReflect.Descriptor instead.
+func (*Impairment) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Impairment) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *Impairment) GetJitterMs() uint32 {
+	if x != nil {
+		return x.JitterMs
+	}
+	return 0
+}
+
+func (x *Impairment) GetLoss() float32 {
+	if x != nil {
+		return x.Loss
+	}
+	return 0
+}
+
+func (x *Impairment) GetCorruption() float32 {
+	if x != nil {
+		return x.Corruption
+	}
+	return 0
+}
+
+func (x *Impairment) GetDuplication() float32 {
+	if x != nil {
+		return x.Duplication
+	}
+	return 0
+}
+
+func (x *Impairment) GetRateKbps() uint64 {
+	if x != nil {
+		return x.RateKbps
+	}
+	return 0
+}
+
 // Config is the k8s pod specific configuration for a node.
 type Config struct {
 	state         protoimpl.MessageState
@@ -349,12 +591,18 @@ type Config struct {
 	//	*Config_Data
 	//	*Config_File
 	ConfigData isConfig_ConfigData `protobuf_oneof:"config_data"`
+	Files      map[string]*File    `protobuf:"bytes,9,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Additional files to mount in config_path keyed by file name.
+	Template   bool                `protobuf:"varint,10,opt,name=template,proto3" json:"template,omitempty"`                                                                                 // Render the startup configuration as a Go text/template.
+	// Image of the sidecar containers the node type adds to the pod.  Only
+	// used by OTG nodes for their traffic engines; all other node types
+	// ignore it.
+	SidecarImage string `protobuf:"bytes,11,opt,name=sidecar_image,json=sidecarImage,proto3" json:"sidecar_image,omitempty"`
 }
 
 func (x *Config) Reset() {
 	*x = Config{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[3]
+		mi := &file_topo_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -367,7 +615,7 @@ func (x *Config) String() string {
 func (*Config) ProtoMessage() {}
 
 func (x *Config) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[3]
+	mi := &file_topo_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -380,7 +628,7 @@ func (x *Config) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Config.ProtoReflect.Descriptor instead.
 func (*Config) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{3}
+	return file_topo_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Config) GetCommand() []string {
@@ -460,6 +708,27 @@ func (x *Config) GetFile() string {
 	return ""
 }
 
+func (x *Config) GetFiles() map[string]*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Config) GetTemplate() bool {
+	if x != nil {
+		return x.Template
+	}
+	return false
+}
+
+func (x *Config) GetSidecarImage() string {
+	if x != nil {
+		return x.SidecarImage
+	}
+	return ""
+}
+
 type isConfig_ConfigData interface {
 	isConfig_ConfigData()
 }
@@ -476,6 +745,87 @@ func (*Config_Data) isConfig_ConfigData() {}
 
 func (*Config_File) isConfig_ConfigData() {}
 
+// File is the contents of a file to place in the pod.
+type File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Contents:
+	//	*File_Data
+	//	*File_Path
+	Contents isFile_Contents `protobuf_oneof:"contents"`
+}
+
+func (x *File) Reset() {
+	*x = File{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*File) ProtoMessage() {}
+
+func (x *File) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *File) GetContents() isFile_Contents {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+func (x *File) GetData() []byte {
+	if x, ok := x.GetContents().(*File_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *File) GetPath() string {
+	if x, ok := x.GetContents().(*File_Path); ok {
+		return x.Path
+	}
+	return ""
+}
+
+type isFile_Contents interface {
+	isFile_Contents()
+}
+
+type File_Data struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3,oneof"` // Byte data for the file.
+}
+
+type File_Path struct {
+	Path string `protobuf:"bytes,2,opt,name=path,proto3,oneof"` // Local file to read for the file.
+}
+
+func (*File_Data) isFile_Contents() {}
+
+func (*File_Path) isFile_Contents() {}
+
 // Service is k8s Service to expose to the cluster
 type Service struct {
 	state         protoimpl.MessageState
@@ -490,7 +840,7 @@ type Service struct {
 func (x *Service) Reset() {
 	*x = Service{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_topo_proto_msgTypes[4]
+		mi := &file_topo_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -503,7 +853,7 @@ func (x *Service) String() string {
 func (*Service) ProtoMessage() {}
 
 func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_topo_proto_msgTypes[4]
+	mi := &file_topo_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +866,7 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Service.ProtoReflect.Descriptor instead.
 func (*Service) Descriptor() ([]byte, []int) {
-	return file_topo_proto_rawDescGZIP(), []int{4}
+	return file_topo_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Service) GetName() string {
@@ -540,96 +890,638 @@ func (x *Service) GetOutside() uint32 {
 	return 0
 }
 
-var File_topo_proto protoreflect.FileDescriptor
+type CreateTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_topo_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x74, 0x6f,
-	0x70, 0x6f, 0x22, 0x62, 0x0a, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0a, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e,
-	0x6f, 0x64, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52,
-	0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x22, 0xd2, 0x04, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x0f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
-	0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34,
-	0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
-	0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
-	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
-	0x6e, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4a,
-	0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x23, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0d, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x43, 0x6f,
-	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7f, 0x0a, 0x04, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12,
-	0x08, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x72, 0x69,
-	0x73, 0x74, 0x61, 0x43, 0x45, 0x4f, 0x53, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x75, 0x6e,
-	0x69, 0x70, 0x65, 0x72, 0x43, 0x45, 0x56, 0x4f, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x69,
-	0x73, 0x63, 0x6f, 0x43, 0x58, 0x52, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x51, 0x75, 0x61, 0x67,
-	0x67, 0x61, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x52, 0x52, 0x10, 0x06, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4a, 0x75, 0x6e, 0x69, 0x70, 0x65, 0x72, 0x56, 0x4d, 0x58, 0x10, 0x07, 0x12, 0x0c, 0x0a,
-	0x08, 0x43, 0x69, 0x73, 0x63, 0x6f, 0x43, 0x53, 0x52, 0x10, 0x08, 0x22, 0x5e, 0x0a, 0x04, 0x4c,
-	0x69, 0x6e, 0x6b, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x61, 0x5f,
-	0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x49, 0x6e, 0x74, 0x12,
-	0x15, 0x0a, 0x06, 0x7a, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x7a, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x7a, 0x5f, 0x69, 0x6e, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x49, 0x6e, 0x74, 0x22, 0xe5, 0x02, 0x0a, 0x06,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
-	0x61, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x03, 0x65, 0x6e,
-	0x76, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03,
-	0x65, 0x6e, 0x76, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x74, 0x72,
-	0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6c,
-	0x65, 0x65, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6c, 0x65, 0x65, 0x70,
-	0x12, 0x14, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x66,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x1a, 0x36, 0x0a, 0x08,
-	0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x4f, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75,
-	0x74, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x75, 0x74,
-	0x73, 0x69, 0x64, 0x65, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x68, 0x66, 0x61, 0x6d, 0x2f, 0x6b, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x74, 0x6f, 0x70, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Topology *Topology `protobuf:"bytes,1,opt,name=topology,proto3" json:"topology,omitempty"` // Topology to create in the cluster.
 }
 
-var (
-	file_topo_proto_rawDescOnce sync.Once
-	file_topo_proto_rawDescData = file_topo_proto_rawDesc
-)
+func (x *CreateTopologyRequest) Reset() {
+	*x = CreateTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopologyRequest) ProtoMessage() {}
+
+func (x *CreateTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopologyRequest.ProtoReflect.Descriptor instead.
+func (*CreateTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateTopologyRequest) GetTopology() *Topology {
+	if x != nil {
+		return x.Topology
+	}
+	return nil
+}
+
+type CreateTopologyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topology *Topology `protobuf:"bytes,1,opt,name=topology,proto3" json:"topology,omitempty"` // Topology as created, including node defaults.
+}
+
+func (x *CreateTopologyResponse) Reset() {
+	*x = CreateTopologyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopologyResponse) ProtoMessage() {}
+
+func (x *CreateTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopologyResponse.ProtoReflect.Descriptor instead.
+func (*CreateTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateTopologyResponse) GetTopology() *Topology {
+	if x != nil {
+		return x.Topology
+	}
+	return nil
+}
+
+type DeleteTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TopologyName string `protobuf:"bytes,1,opt,name=topology_name,json=topologyName,proto3" json:"topology_name,omitempty"` // Name of the topology to delete.
+}
+
+func (x *DeleteTopologyRequest) Reset() {
+	*x = DeleteTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTopologyRequest) ProtoMessage() {}
+
+func (x *DeleteTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTopologyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteTopologyRequest) GetTopologyName() string {
+	if x != nil {
+		return x.TopologyName
+	}
+	return ""
+}
+
+type DeleteTopologyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteTopologyResponse) Reset() {
+	*x = DeleteTopologyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTopologyResponse) ProtoMessage() {}
+
+func (x *DeleteTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTopologyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{11}
+}
+
+type ShowTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TopologyName string `protobuf:"bytes,1,opt,name=topology_name,json=topologyName,proto3" json:"topology_name,omitempty"` // Name of the topology to show.
+}
+
+func (x *ShowTopologyRequest) Reset() {
+	*x = ShowTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShowTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowTopologyRequest) ProtoMessage() {}
+
+func (x *ShowTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowTopologyRequest.ProtoReflect.Descriptor instead.
+func (*ShowTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ShowTopologyRequest) GetTopologyName() string {
+	if x != nil {
+		return x.TopologyName
+	}
+	return ""
+}
+
+type ShowTopologyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topology *Topology     `protobuf:"bytes,1,opt,name=topology,proto3" json:"topology,omitempty"` // Topology as created, including node defaults.
+	Nodes    []*NodeStatus `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`       // Current state of each node pod.
+}
+
+func (x *ShowTopologyResponse) Reset() {
+	*x = ShowTopologyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShowTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowTopologyResponse) ProtoMessage() {}
+
+func (x *ShowTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowTopologyResponse.ProtoReflect.Descriptor instead.
+func (*ShowTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShowTopologyResponse) GetTopology() *Topology {
+	if x != nil {
+		return x.Topology
+	}
+	return nil
+}
+
+func (x *ShowTopologyResponse) GetNodes() []*NodeStatus {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// NodeStatus is the current state of a node's pod in the cluster.
+type NodeStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // Name of the node.
+	Phase string `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"` // Pod phase (Pending, Running, ...).
+	Ip    string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`       // Pod IP address.
+}
+
+func (x *NodeStatus) Reset() {
+	*x = NodeStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeStatus) ProtoMessage() {}
+
+func (x *NodeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeStatus.ProtoReflect.Descriptor instead.
+func (*NodeStatus) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NodeStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *NodeStatus) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type ListTopologiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListTopologiesRequest) Reset() {
+	*x = ListTopologiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTopologiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopologiesRequest) ProtoMessage() {}
+
+func (x *ListTopologiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopologiesRequest.ProtoReflect.Descriptor instead.
+func (*ListTopologiesRequest) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{15}
+}
+
+type ListTopologiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topologies []*Topology `protobuf:"bytes,1,rep,name=topologies,proto3" json:"topologies,omitempty"` // Topologies managed by the server.
+}
+
+func (x *ListTopologiesResponse) Reset() {
+	*x = ListTopologiesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_topo_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTopologiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopologiesResponse) ProtoMessage() {}
+
+func (x *ListTopologiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_topo_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopologiesResponse.ProtoReflect.Descriptor instead.
+func (*ListTopologiesResponse) Descriptor() ([]byte, []int) {
+	return file_topo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTopologiesResponse) GetTopologies() []*Topology {
+	if x != nil {
+		return x.Topologies
+	}
+	return nil
+}
+
+var File_topo_proto protoreflect.FileDescriptor
+
+var file_topo_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x74, 0x6f,
+	0x70, 0x6f, 0x22, 0x94, 0x01, 0x0a, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x6f,
+	0x70, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x22, 0x90, 0x01, 0x0a, 0x0a, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x69, 0x6e, 0x6b,
+	0x5f, 0x69, 0x70, 0x76, 0x34, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x69, 0x6e,
+	0x6b, 0x49, 0x70, 0x76, 0x34, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x69, 0x70,
+	0x76, 0x36, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x49, 0x70,
+	0x76, 0x36, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x6f, 0x70, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x69,
+	0x70, 0x76, 0x34, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x6f, 0x6f, 0x70, 0x62,
+	0x61, 0x63, 0x6b, 0x49, 0x70, 0x76, 0x34, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x6f, 0x70, 0x62,
+	0x61, 0x63, 0x6b, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6c, 0x6f, 0x6f, 0x70, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x70, 0x76, 0x36, 0x22, 0xc1, 0x05, 0x0a,
+	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x24,
+	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x6f,
+	0x70, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x6c, 0x6f, 0x6f, 0x70, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x70, 0x76, 0x34, 0x12, 0x23,
+	0x0a, 0x0d, 0x6c, 0x6f, 0x6f, 0x70, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x6f, 0x6f, 0x70, 0x62, 0x61, 0x63, 0x6b, 0x49,
+	0x70, 0x76, 0x36, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4a,
+	0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x23, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x43, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa3, 0x01, 0x0a, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x72,
+	0x69, 0x73, 0x74, 0x61, 0x43, 0x45, 0x4f, 0x53, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x75,
+	0x6e, 0x69, 0x70, 0x65, 0x72, 0x43, 0x45, 0x56, 0x4f, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x43,
+	0x69, 0x73, 0x63, 0x6f, 0x43, 0x58, 0x52, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x51, 0x75, 0x61,
+	0x67, 0x67, 0x61, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x52, 0x52, 0x10, 0x06, 0x12, 0x0e,
+	0x0a, 0x0a, 0x4a, 0x75, 0x6e, 0x69, 0x70, 0x65, 0x72, 0x56, 0x4d, 0x58, 0x10, 0x07, 0x12, 0x0c,
+	0x0a, 0x08, 0x43, 0x69, 0x73, 0x63, 0x6f, 0x43, 0x53, 0x52, 0x10, 0x08, 0x12, 0x0c, 0x0a, 0x08,
+	0x4e, 0x6f, 0x6b, 0x69, 0x61, 0x53, 0x52, 0x4c, 0x10, 0x09, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x6f,
+	0x6e, 0x69, 0x63, 0x56, 0x53, 0x10, 0x0a, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x54, 0x47, 0x10, 0x0b,
+	0x22, 0xec, 0x01, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x5f, 0x6e,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x13, 0x0a, 0x05, 0x61, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x49, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x7a, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x7a, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x05,
+	0x7a, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x49, 0x6e,
+	0x74, 0x12, 0x30, 0x0a, 0x0a, 0x69, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x49, 0x6d, 0x70,
+	0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x69, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x49, 0x70, 0x76, 0x34, 0x12, 0x15, 0x0a, 0x06, 0x7a, 0x5f,
+	0x69, 0x70, 0x76, 0x34, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x7a, 0x49, 0x70, 0x76,
+	0x34, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x5f, 0x69, 0x70, 0x76, 0x36, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x61, 0x49, 0x70, 0x76, 0x36, 0x12, 0x15, 0x0a, 0x06, 0x7a, 0x5f, 0x69, 0x70,
+	0x76, 0x36, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x7a, 0x49, 0x70, 0x76, 0x36, 0x22,
+	0xb7, 0x01, 0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x69, 0x74,
+	0x74, 0x65, 0x72, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6a, 0x69,
+	0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x6c, 0x6f, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
+	0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a,
+	0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x0b, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x62, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x72, 0x61, 0x74, 0x65, 0x4b, 0x62, 0x70, 0x73, 0x22, 0x9b, 0x04, 0x0a, 0x06, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72,
+	0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e,
+	0x76, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6c, 0x65, 0x65,
+	0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6c, 0x65, 0x65, 0x70, 0x12, 0x14,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x66, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72,
+	0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69,
+	0x64, 0x65, 0x63, 0x61, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e,
+	0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x44, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x20, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0a, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3e, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x14, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x42, 0x0a, 0x0a, 0x08, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x4f, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65, 0x22, 0x43, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2a, 0x0a, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x52, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x22, 0x44, 0x0a,
+	0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f,
+	0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x22, 0x3c, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x22, 0x18, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3a, 0x0a, 0x13, 0x53,
+	0x68, 0x6f, 0x77, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x6a, 0x0a, 0x14, 0x53, 0x68, 0x6f, 0x77, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2a, 0x0a, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x52, 0x08, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x6f, 0x70,
+	0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x22, 0x17, 0x0a, 0x15, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x48, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e,
+	0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f,
+	0x67, 0x79, 0x52, 0x0a, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x65, 0x73, 0x32, 0xc7,
+	0x02, 0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x4d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x12, 0x4d, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x79, 0x12, 0x1b, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x12, 0x1b, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x47, 0x0a, 0x0c, 0x53, 0x68, 0x6f, 0x77, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x12, 0x19, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x54, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x6f,
+	0x70, 0x6f, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x74, 0x6f,
+	0x70, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x6f, 0x70, 0x6f, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x2d, 0x66, 0x61, 0x6d, 0x2f, 0x6b, 0x6e, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x6f, 0x70, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_topo_proto_rawDescOnce sync.Once
+	file_topo_proto_rawDescData = file_topo_proto_rawDesc
+)
 
 func file_topo_proto_rawDescGZIP() []byte {
 	file_topo_proto_rawDescOnce.Do(func() {
@@ -639,34 +1531,64 @@ func file_topo_proto_rawDescGZIP() []byte {
 }
 
 var file_topo_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_topo_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_topo_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_topo_proto_goTypes = []interface{}{
-	(Node_Type)(0),   // 0: topo.Node.Type
-	(*Topology)(nil), // 1: topo.Topology
-	(*Node)(nil),     // 2: topo.Node
-	(*Link)(nil),     // 3: topo.Link
-	(*Config)(nil),   // 4: topo.Config
-	(*Service)(nil),  // 5: topo.Service
-	nil,              // 6: topo.Node.LabelsEntry
-	nil,              // 7: topo.Node.ServicesEntry
-	nil,              // 8: topo.Node.ConstraintsEntry
-	nil,              // 9: topo.Config.EnvEntry
+	(Node_Type)(0),                 // 0: topo.Node.Type
+	(*Topology)(nil),               // 1: topo.Topology
+	(*Addressing)(nil),             // 2: topo.Addressing
+	(*Node)(nil),                   // 3: topo.Node
+	(*Link)(nil),                   // 4: topo.Link
+	(*Impairment)(nil),             // 5: topo.Impairment
+	(*Config)(nil),                 // 6: topo.Config
+	(*File)(nil),                   // 7: topo.File
+	(*Service)(nil),                // 8: topo.Service
+	(*CreateTopologyRequest)(nil),  // 9: topo.CreateTopologyRequest
+	(*CreateTopologyResponse)(nil), // 10: topo.CreateTopologyResponse
+	(*DeleteTopologyRequest)(nil),  // 11: topo.DeleteTopologyRequest
+	(*DeleteTopologyResponse)(nil), // 12: topo.DeleteTopologyResponse
+	(*ShowTopologyRequest)(nil),    // 13: topo.ShowTopologyRequest
+	(*ShowTopologyResponse)(nil),   // 14: topo.ShowTopologyResponse
+	(*NodeStatus)(nil),             // 15: topo.NodeStatus
+	(*ListTopologiesRequest)(nil),  // 16: topo.ListTopologiesRequest
+	(*ListTopologiesResponse)(nil), // 17: topo.ListTopologiesResponse
+	nil,                            // 18: topo.Node.LabelsEntry
+	nil,                            // 19: topo.Node.ServicesEntry
+	nil,                            // 20: topo.Node.ConstraintsEntry
+	nil,                            // 21: topo.Config.EnvEntry
+	nil,                            // 22: topo.Config.FilesEntry
 }
 var file_topo_proto_depIdxs = []int32{
-	2, // 0: topo.Topology.nodes:type_name -> topo.Node
-	3, // 1: topo.Topology.links:type_name -> topo.Link
-	0, // 2: topo.Node.type:type_name -> topo.Node.Type
-	6, // 3: topo.Node.labels:type_name -> topo.Node.LabelsEntry
-	4, // 4: topo.Node.config:type_name -> topo.Config
-	7, // 5: topo.Node.services:type_name -> topo.Node.ServicesEntry
-	8, // 6: topo.Node.constraints:type_name -> topo.Node.ConstraintsEntry
-	9, // 7: topo.Config.env:type_name -> topo.Config.EnvEntry
-	5, // 8: topo.Node.ServicesEntry.value:type_name -> topo.Service
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	3,  // 0: topo.Topology.nodes:type_name -> topo.Node
+	4,  // 1: topo.Topology.links:type_name -> topo.Link
+	2,  // 2: topo.Topology.addressing:type_name -> topo.Addressing
+	0,  // 3: topo.Node.type:type_name -> topo.Node.Type
+	18, // 4: topo.Node.labels:type_name -> topo.Node.LabelsEntry
+	6,  // 5: topo.Node.config:type_name -> topo.Config
+	19, // 6: topo.Node.services:type_name -> topo.Node.ServicesEntry
+	20, // 7: topo.Node.constraints:type_name -> topo.Node.ConstraintsEntry
+	5,  // 8: topo.Link.impairment:type_name -> topo.Impairment
+	21, // 9: topo.Config.env:type_name -> topo.Config.EnvEntry
+	22, // 10: topo.Config.files:type_name -> topo.Config.FilesEntry
+	1,  // 11: topo.CreateTopologyRequest.topology:type_name -> topo.Topology
+	1,  // 12: topo.CreateTopologyResponse.topology:type_name -> topo.Topology
+	1,  // 13: topo.ShowTopologyResponse.topology:type_name -> topo.Topology
+	15, // 14: topo.ShowTopologyResponse.nodes:type_name -> topo.NodeStatus
+	1,  // 15: topo.ListTopologiesResponse.topologies:type_name -> topo.Topology
+	8,  // 16: topo.Node.ServicesEntry.value:type_name -> topo.Service
+	7,  // 17: topo.Config.FilesEntry.value:type_name -> topo.File
+	9,  // 18: topo.TopologyManager.CreateTopology:input_type -> topo.CreateTopologyRequest
+	11, // 19: topo.TopologyManager.DeleteTopology:input_type -> topo.DeleteTopologyRequest
+	13, // 20: topo.TopologyManager.ShowTopology:input_type -> topo.ShowTopologyRequest
+	16, // 21: topo.TopologyManager.ListTopologies:input_type -> topo.ListTopologiesRequest
+	10, // 22: topo.TopologyManager.CreateTopology:output_type -> topo.CreateTopologyResponse
+	12, // 23: topo.TopologyManager.DeleteTopology:output_type -> topo.DeleteTopologyResponse
+	14, // 24: topo.TopologyManager.ShowTopology:output_type -> topo.ShowTopologyResponse
+	17, // 25: topo.TopologyManager.ListTopologies:output_type -> topo.ListTopologiesResponse
+	22, // [22:26] is the sub-list for method output_type
+	18, // [18:22] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_topo_proto_init() }
@@ -688,7 +1610,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Node); i {
+			switch v := v.(*Addressing); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -700,7 +1622,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Link); i {
+			switch v := v.(*Node); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -712,7 +1634,7 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Config); i {
+			switch v := v.(*Link); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -724,6 +1646,42 @@ func file_topo_proto_init() {
 			}
 		}
 		file_topo_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Impairment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Service); i {
 			case 0:
 				return &v.state
@@ -735,20 +1693,132 @@ func file_topo_proto_init() {
 				return nil
 			}
 		}
+		file_topo_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTopologyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTopologyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTopologyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTopologyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowTopologyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowTopologyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTopologiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_topo_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTopologiesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_topo_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_topo_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*Config_Data)(nil),
 		(*Config_File)(nil),
 	}
+	file_topo_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*File_Data)(nil),
+		(*File_Path)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_topo_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   9,
+			NumMessages:   22,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_topo_proto_goTypes,
 		DependencyIndexes: file_topo_proto_depIdxs,
@@ -760,3 +1830,191 @@ func file_topo_proto_init() {
 	file_topo_proto_goTypes = nil
 	file_topo_proto_depIdxs = nil
 }
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TopologyManagerClient is the client API for TopologyManager service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TopologyManagerClient interface {
+	CreateTopology(ctx context.Context, in *CreateTopologyRequest, opts ...grpc.CallOption) (*CreateTopologyResponse, error)
+	DeleteTopology(ctx context.Context, in *DeleteTopologyRequest, opts ...grpc.CallOption) (*DeleteTopologyResponse, error)
+	ShowTopology(ctx context.Context, in *ShowTopologyRequest, opts ...grpc.CallOption) (*ShowTopologyResponse, error)
+	ListTopologies(ctx context.Context, in *ListTopologiesRequest, opts ...grpc.CallOption) (*ListTopologiesResponse, error)
+}
+
+type topologyManagerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTopologyManagerClient(cc grpc.ClientConnInterface) TopologyManagerClient {
+	return &topologyManagerClient{cc}
+}
+
+func (c *topologyManagerClient) CreateTopology(ctx context.Context, in *CreateTopologyRequest, opts ...grpc.CallOption) (*CreateTopologyResponse, error) {
+	out := new(CreateTopologyResponse)
+	err := c.cc.Invoke(ctx, "/topo.TopologyManager/CreateTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) DeleteTopology(ctx context.Context, in *DeleteTopologyRequest, opts ...grpc.CallOption) (*DeleteTopologyResponse, error) {
+	out := new(DeleteTopologyResponse)
+	err := c.cc.Invoke(ctx, "/topo.TopologyManager/DeleteTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) ShowTopology(ctx context.Context, in *ShowTopologyRequest, opts ...grpc.CallOption) (*ShowTopologyResponse, error) {
+	out := new(ShowTopologyResponse)
+	err := c.cc.Invoke(ctx, "/topo.TopologyManager/ShowTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyManagerClient) ListTopologies(ctx context.Context, in *ListTopologiesRequest, opts ...grpc.CallOption) (*ListTopologiesResponse, error) {
+	out := new(ListTopologiesResponse)
+	err := c.cc.Invoke(ctx, "/topo.TopologyManager/ListTopologies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TopologyManagerServer is the server API for TopologyManager service.
+type TopologyManagerServer interface {
+	CreateTopology(context.Context, *CreateTopologyRequest) (*CreateTopologyResponse, error)
+	DeleteTopology(context.Context, *DeleteTopologyRequest) (*DeleteTopologyResponse, error)
+	ShowTopology(context.Context, *ShowTopologyRequest) (*ShowTopologyResponse, error)
+	ListTopologies(context.Context, *ListTopologiesRequest) (*ListTopologiesResponse, error)
+}
+
+// UnimplementedTopologyManagerServer can be embedded to have forward compatible implementations.
+type UnimplementedTopologyManagerServer struct {
+}
+
+func (*UnimplementedTopologyManagerServer) CreateTopology(context.Context, *CreateTopologyRequest) (*CreateTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopology not implemented")
+}
+func (*UnimplementedTopologyManagerServer) DeleteTopology(context.Context, *DeleteTopologyRequest) (*DeleteTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTopology not implemented")
+}
+func (*UnimplementedTopologyManagerServer) ShowTopology(context.Context, *ShowTopologyRequest) (*ShowTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShowTopology not implemented")
+}
+func (*UnimplementedTopologyManagerServer) ListTopologies(context.Context, *ListTopologiesRequest) (*ListTopologiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopologies not implemented")
+}
+
+func RegisterTopologyManagerServer(s *grpc.Server, srv TopologyManagerServer) {
+	s.RegisterService(&_TopologyManager_serviceDesc, srv)
+}
+
+func _TopologyManager_CreateTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).CreateTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/topo.TopologyManager/CreateTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).CreateTopology(ctx, req.(*CreateTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_DeleteTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).DeleteTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/topo.TopologyManager/DeleteTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).DeleteTopology(ctx, req.(*DeleteTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_ShowTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShowTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).ShowTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/topo.TopologyManager/ShowTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).ShowTopology(ctx, req.(*ShowTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyManager_ListTopologies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopologiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyManagerServer).ListTopologies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/topo.TopologyManager/ListTopologies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyManagerServer).ListTopologies(ctx, req.(*ListTopologiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TopologyManager_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "topo.TopologyManager",
+	HandlerType: (*TopologyManagerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTopology",
+			Handler:    _TopologyManager_CreateTopology_Handler,
+		},
+		{
+			MethodName: "DeleteTopology",
+			Handler:    _TopologyManager_DeleteTopology_Handler,
+		},
+		{
+			MethodName: "ShowTopology",
+			Handler:    _TopologyManager_ShowTopology_Handler,
+		},
+		{
+			MethodName: "ListTopologies",
+			Handler:    _TopologyManager_ListTopologies_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "topo.proto",
+}
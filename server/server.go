@@ -15,27 +15,254 @@
 package main
 
 import (
-	"log"
+	"context"
+	"flag"
 	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
-)
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/client-go/util/homedir"
 
-type Server struct{}
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo"
+	"github.com/h-fam/kne/topo/node"
+)
 
-const (
-	port = ":50051"
+var (
+	port    = flag.String("port", ":50051", "Address to listen on for gRPC requests")
+	kubecfg = flag.String("kubecfg", defaultKubeCfg(), "kubeconfig file (in-cluster configuration is tried first)")
 )
 
+func defaultKubeCfg() string {
+	if home := homedir.HomeDir(); home != "" {
+		return filepath.Join(home, ".kube", "config")
+	}
+	return ""
+}
+
+// server implements the TopologyManager service.
 type server struct {
+	kubecfg string
+
+	mu    sync.Mutex
+	topos map[string]*managedTopology
+}
+
+type managedTopology struct {
+	tpb *topopb.Topology
+	m   *topo.Manager
+	// cancel stops the post create steps of the nodes, which run in the
+	// background once the topology is pushed.
+	cancel context.CancelFunc
+}
+
+func newServer(kubecfg string) *server {
+	return &server{
+		kubecfg: kubecfg,
+		topos:   map[string]*managedTopology{},
+	}
+}
+
+// restore recovers the topologies which exist in the cluster, e.g. those
+// created before the server was restarted.
+func (s *server) restore(ctx context.Context) error {
+	tpbs, err := topo.Topologies(ctx, s.kubecfg)
+	if err != nil {
+		return err
+	}
+	// New topologies must not be assigned the node ports of the restored
+	// ones, which were assigned by an earlier server.
+	for _, tpb := range tpbs {
+		for _, n := range tpb.Nodes {
+			for _, svc := range n.Services {
+				node.ReservePort(svc.GetOutside())
+			}
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tpb := range tpbs {
+		m, err := topo.New(s.kubecfg, tpb)
+		if err != nil {
+			return err
+		}
+		if err := m.Load(ctx); err != nil {
+			log.Warnf("Failed to load topology %q: %v", tpb.Name, err)
+			continue
+		}
+		s.topos[tpb.Name] = &managedTopology{tpb: tpb, m: m, cancel: func() {}}
+		log.Infof("Restored topology %q", tpb.Name)
+	}
+	return nil
+}
+
+func (s *server) get(name string) (*managedTopology, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.topos[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "topology %q not found", name)
+	}
+	return t, nil
+}
+
+// CreateTopology loads the requested topology and pushes it to the cluster.
+func (s *server) CreateTopology(ctx context.Context, req *topopb.CreateTopologyRequest) (*topopb.CreateTopologyResponse, error) {
+	tpb := req.GetTopology()
+	if tpb.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "topology name must be provided")
+	}
+	tpb = proto.Clone(tpb).(*topopb.Topology)
+	s.mu.Lock()
+	if _, ok := s.topos[tpb.Name]; ok {
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.AlreadyExists, "topology %q already exists", tpb.Name)
+	}
+	// Reserve the name so concurrent requests for the same topology fail fast.
+	// A nil entry marks a topology which is being created or deleted.
+	s.topos[tpb.Name] = nil
+	s.mu.Unlock()
+	t, err := s.create(ctx, tpb)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		delete(s.topos, tpb.Name)
+		return nil, err
+	}
+	s.topos[tpb.Name] = t
+	return &topopb.CreateTopologyResponse{
+		Topology: tpb,
+	}, nil
+}
+
+func (s *server) create(ctx context.Context, tpb *topopb.Topology) (*managedTopology, error) {
+	if errs := topo.Validate(tpb); len(errs) != 0 {
+		var msgs []string
+		for _, e := range errs {
+			msgs = append(msgs, e.Error())
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "invalid topology: %s", strings.Join(msgs, "; "))
+	}
+	m, err := topo.New(s.kubecfg, tpb)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create topology manager: %v", err)
+	}
+	if err := m.Load(ctx); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to load topology: %v", err)
+	}
+	if err := m.Push(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push topology: %v", err)
+	}
+	log.Infof("Topology %q created", tpb.Name)
+	// The request must not wait for the nodes to boot, which may never
+	// happen, e.g. if an image cannot be pulled.
+	pcCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer cancel()
+		if err := m.PostCreate(pcCtx, m.NodeNames()); err != nil {
+			log.Errorf("Post create of topology %q failed: %v", tpb.Name, err)
+		}
+	}()
+	return &managedTopology{tpb: tpb, m: m, cancel: cancel}, nil
+}
+
+// DeleteTopology deletes a topology previously created by the server.
+func (s *server) DeleteTopology(ctx context.Context, req *topopb.DeleteTopologyRequest) (*topopb.DeleteTopologyResponse, error) {
+	name := req.GetTopologyName()
+	s.mu.Lock()
+	t, ok := s.topos[name]
+	switch {
+	case !ok:
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.NotFound, "topology %q not found", name)
+	case t == nil:
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.FailedPrecondition, "topology %q is being created or deleted", name)
+	}
+	// Reserve the name while deleting so concurrent requests for the same
+	// topology fail fast.
+	s.topos[name] = nil
+	s.mu.Unlock()
+	t.cancel()
+	if err := t.m.Delete(ctx); err != nil {
+		s.mu.Lock()
+		s.topos[name] = t
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.Internal, "failed to delete topology: %v", err)
+	}
+	s.mu.Lock()
+	delete(s.topos, name)
+	s.mu.Unlock()
+	log.Infof("Topology %q deleted", name)
+	return &topopb.DeleteTopologyResponse{}, nil
+}
+
+// ShowTopology returns the topology and the current state of its nodes.
+func (s *server) ShowTopology(ctx context.Context, req *topopb.ShowTopologyRequest) (*topopb.ShowTopologyResponse, error) {
+	t, err := s.get(req.GetTopologyName())
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, status.Errorf(codes.Unavailable, "topology %q is being created or deleted", req.GetTopologyName())
+	}
+	r, err := t.m.Resources(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get topology resources: %v", err)
+	}
+	resp := &topopb.ShowTopologyResponse{
+		Topology: t.tpb,
+	}
+	for _, p := range r.Pods {
+		resp.Nodes = append(resp.Nodes, &topopb.NodeStatus{
+			Name:  p.Name,
+			Phase: string(p.Status.Phase),
+			Ip:    p.Status.PodIP,
+		})
+	}
+	sort.Slice(resp.Nodes, func(i, j int) bool {
+		return resp.Nodes[i].Name < resp.Nodes[j].Name
+	})
+	return resp, nil
+}
+
+// ListTopologies returns all topologies created by the server.
+func (s *server) ListTopologies(ctx context.Context, req *topopb.ListTopologiesRequest) (*topopb.ListTopologiesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &topopb.ListTopologiesResponse{}
+	for _, t := range s.topos {
+		if t == nil {
+			continue
+		}
+		resp.Topologies = append(resp.Topologies, t.tpb)
+	}
+	sort.Slice(resp.Topologies, func(i, j int) bool {
+		return resp.Topologies[i].Name < resp.Topologies[j].Name
+	})
+	return resp, nil
 }
 
 func main() {
-	lis, err := net.Listen("tcp", port)
+	flag.Parse()
+	lis, err := net.Listen("tcp", *port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
+	srv := newServer(*kubecfg)
+	if err := srv.restore(context.Background()); err != nil {
+		log.Warnf("Failed to restore topologies from the cluster: %v", err)
+	}
 	s := grpc.NewServer()
+	topopb.RegisterTopologyManagerServer(s, srv)
+	log.Infof("TopologyManager listening on %s", lis.Addr())
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -0,0 +1,241 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+func family(bits int) string {
+	if bits == 32 {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// parseCIDR parses addr, an address in CIDR notation of the family with bits
+// address bits.
+func parseCIDR(addr string, bits int) (net.IP, *net.IPNet, error) {
+	ip, prefix, err := net.ParseCIDR(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, b := prefix.Mask.Size(); b != bits {
+		return nil, nil, fmt.Errorf("%q is not an %s address", addr, family(bits))
+	}
+	return ip, prefix, nil
+}
+
+// pool hands out consecutive blocks of a fixed prefix length from a prefix.
+type pool struct {
+	name   string
+	prefix *net.IPNet
+	bits   int
+	ones   int             // Prefix length of the assigned blocks.
+	next   *big.Int        // Start of the next candidate block.
+	used   map[string]bool // Starts of blocks which must not be assigned.
+}
+
+// newPool returns a pool of /ones blocks from cidr.  A nil pool is returned
+// if cidr is empty.
+func newPool(name, cidr string, bits, ones int) (*pool, error) {
+	if cidr == "" {
+		return nil, nil
+	}
+	_, prefix, err := parseCIDR(cidr, bits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s pool: %w", name, err)
+	}
+	if pOnes, _ := prefix.Mask.Size(); pOnes > ones {
+		return nil, fmt.Errorf("invalid %s pool: %q is smaller than a /%d", name, cidr, ones)
+	}
+	return &pool{
+		name:   name,
+		prefix: prefix,
+		bits:   bits,
+		ones:   ones,
+		next:   new(big.Int).SetBytes(ipBytes(prefix.IP, bits)),
+		used:   map[string]bool{},
+	}, nil
+}
+
+func ipBytes(ip net.IP, bits int) []byte {
+	if bits == 32 {
+		return ip.To4()
+	}
+	return ip.To16()
+}
+
+func (p *pool) ip(i *big.Int) net.IP {
+	b := make([]byte, p.bits/8)
+	return net.IP(i.FillBytes(b))
+}
+
+// reserve keeps the block containing the explicitly set address addr of the
+// family with bits address bits from being assigned by p, which may be nil.
+// Addresses outside the pool are only checked to be valid.
+func (p *pool) reserve(addr string, bits int) error {
+	if addr == "" {
+		return nil
+	}
+	ip, _, err := parseCIDR(addr, bits)
+	if err != nil {
+		return err
+	}
+	if p != nil && p.prefix.Contains(ip) {
+		p.used[ip.Mask(net.CIDRMask(p.ones, p.bits)).String()] = true
+	}
+	return nil
+}
+
+// allocate returns the first address of the next free block.
+func (p *pool) allocate() (*big.Int, error) {
+	step := new(big.Int).Lsh(big.NewInt(1), uint(p.bits-p.ones))
+	for {
+		start := new(big.Int).Set(p.next)
+		// Blocks past the end of the address space do not fit in an IP.
+		if start.BitLen() > p.bits {
+			return nil, fmt.Errorf("%s pool %s is exhausted", p.name, p.prefix)
+		}
+		ip := p.ip(start)
+		if !p.prefix.Contains(ip) {
+			return nil, fmt.Errorf("%s pool %s is exhausted", p.name, p.prefix)
+		}
+		p.next.Add(p.next, step)
+		if !p.used[ip.String()] {
+			return start, nil
+		}
+	}
+}
+
+// addr returns the address offset from i in CIDR notation.
+func (p *pool) addr(i *big.Int, offset int64) string {
+	ip := p.ip(new(big.Int).Add(i, big.NewInt(offset)))
+	return fmt.Sprintf("%s/%d", ip, p.ones)
+}
+
+// addressPools holds the pools of a topology, nil pools are not configured.
+type addressPools struct {
+	link4, link6, lo4, lo6 *pool
+}
+
+func newAddressPools(a *topopb.Addressing) (*addressPools, error) {
+	var (
+		p   addressPools
+		err error
+	)
+	if p.link4, err = newPool("link_ipv4", a.GetLinkIpv4(), 32, 31); err != nil {
+		return nil, err
+	}
+	if p.link6, err = newPool("link_ipv6", a.GetLinkIpv6(), 128, 127); err != nil {
+		return nil, err
+	}
+	if p.lo4, err = newPool("loopback_ipv4", a.GetLoopbackIpv4(), 32, 32); err != nil {
+		return nil, err
+	}
+	if p.lo6, err = newPool("loopback_ipv6", a.GetLoopbackIpv6(), 128, 128); err != nil {
+		return nil, err
+	}
+	// Loopbacks are not assigned the network address of their pool.
+	for _, lo := range []*pool{p.lo4, p.lo6} {
+		if lo != nil {
+			lo.used[lo.prefix.IP.String()] = true
+		}
+	}
+	return &p, nil
+}
+
+// assignAddresses assigns addresses from the pools of t to the links and
+// nodes which have none.  Links are assigned both ends of a /31 (or /127)
+// and nodes a /32 (or /128) loopback.  Explicitly set addresses are checked
+// to be valid even if t has no pools.
+func assignAddresses(t *topopb.Topology) error {
+	p, err := newAddressPools(t.GetAddressing())
+	if err != nil {
+		return err
+	}
+	for _, l := range t.Links {
+		for _, r := range []struct {
+			p    *pool
+			addr string
+			bits int
+		}{
+			{p.link4, l.AIpv4, 32}, {p.link4, l.ZIpv4, 32},
+			{p.link6, l.AIpv6, 128}, {p.link6, l.ZIpv6, 128},
+		} {
+			if err := r.p.reserve(r.addr, r.bits); err != nil {
+				return fmt.Errorf("link %s:%s %s:%s: %w", l.ANode, l.AInt, l.ZNode, l.ZInt, err)
+			}
+		}
+	}
+	for _, n := range t.Nodes {
+		if err := p.lo4.reserve(n.LoopbackIpv4, 32); err != nil {
+			return fmt.Errorf("node %q: %w", n.Name, err)
+		}
+		if err := p.lo6.reserve(n.LoopbackIpv6, 128); err != nil {
+			return fmt.Errorf("node %q: %w", n.Name, err)
+		}
+	}
+	for _, l := range t.Links {
+		for _, a := range []struct {
+			p    *pool
+			a, z *string
+		}{
+			{p.link4, &l.AIpv4, &l.ZIpv4},
+			{p.link6, &l.AIpv6, &l.ZIpv6},
+		} {
+			if a.p == nil || *a.a != "" || *a.z != "" {
+				continue
+			}
+			i, err := a.p.allocate()
+			if err != nil {
+				return fmt.Errorf("link %s:%s %s:%s: %w", l.ANode, l.AInt, l.ZNode, l.ZInt, err)
+			}
+			*a.a, *a.z = a.p.addr(i, 0), a.p.addr(i, 1)
+		}
+	}
+	for _, n := range t.Nodes {
+		for _, a := range []struct {
+			p    *pool
+			addr *string
+		}{
+			{p.lo4, &n.LoopbackIpv4},
+			{p.lo6, &n.LoopbackIpv6},
+		} {
+			if a.p == nil || *a.addr != "" {
+				continue
+			}
+			i, err := a.p.allocate()
+			if err != nil {
+				return fmt.Errorf("node %q: %w", n.Name, err)
+			}
+			*a.addr = a.p.addr(i, 0)
+		}
+	}
+	return nil
+}
+
+// linkIP returns the address meshnet configures on a link end, the IPv4
+// address if both are set.
+func linkIP(ipv4, ipv6 string) string {
+	if ipv4 != "" {
+		return ipv4
+	}
+	return ipv6
+}
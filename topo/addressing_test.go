@@ -0,0 +1,212 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+func testLink(a, z string) *topopb.Link {
+	return &topopb.Link{ANode: a, AInt: "eth1", ZNode: z, ZInt: "eth1"}
+}
+
+func TestAssignAddresses(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      *topopb.Topology
+		want    *topopb.Topology
+		wantErr string
+	}{{
+		desc: "no pools",
+		in: &topopb.Topology{
+			Nodes: []*topopb.Node{{Name: "r1"}, {Name: "r2"}},
+			Links: []*topopb.Link{testLink("r1", "r2")},
+		},
+		want: &topopb.Topology{
+			Nodes: []*topopb.Node{{Name: "r1"}, {Name: "r2"}},
+			Links: []*topopb.Link{testLink("r1", "r2")},
+		},
+	}, {
+		desc: "ipv4",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "10.0.0.0/30", LoopbackIpv4: "192.168.0.0/30"},
+			Nodes:      []*topopb.Node{{Name: "r1"}, {Name: "r2"}},
+			Links:      []*topopb.Link{testLink("r1", "r2"), testLink("r2", "r1")},
+		},
+		want: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "10.0.0.0/30", LoopbackIpv4: "192.168.0.0/30"},
+			Nodes: []*topopb.Node{
+				{Name: "r1", LoopbackIpv4: "192.168.0.1/32"},
+				{Name: "r2", LoopbackIpv4: "192.168.0.2/32"},
+			},
+			Links: []*topopb.Link{
+				{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1", AIpv4: "10.0.0.0/31", ZIpv4: "10.0.0.1/31"},
+				{ANode: "r2", AInt: "eth1", ZNode: "r1", ZInt: "eth1", AIpv4: "10.0.0.2/31", ZIpv4: "10.0.0.3/31"},
+			},
+		},
+	}, {
+		desc: "ipv6",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv6: "2001:db8::/126", LoopbackIpv6: "2001:db8:1::/127"},
+			Nodes:      []*topopb.Node{{Name: "r1"}},
+			Links:      []*topopb.Link{testLink("r1", "r2")},
+		},
+		want: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv6: "2001:db8::/126", LoopbackIpv6: "2001:db8:1::/127"},
+			Nodes:      []*topopb.Node{{Name: "r1", LoopbackIpv6: "2001:db8:1::1/128"}},
+			Links: []*topopb.Link{
+				{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1", AIpv6: "2001:db8::/127", ZIpv6: "2001:db8::1/127"},
+			},
+		},
+	}, {
+		desc: "explicit addresses are kept and reserved",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "10.0.0.0/29", LoopbackIpv4: "192.168.0.0/29"},
+			Nodes: []*topopb.Node{
+				{Name: "r1"},
+				{Name: "r2", LoopbackIpv4: "192.168.0.1/32"},
+				{Name: "r3", LoopbackIpv4: "172.16.0.1/32"},
+			},
+			Links: []*topopb.Link{
+				testLink("r1", "r2"),
+				{ANode: "r2", AInt: "eth2", ZNode: "r3", ZInt: "eth1", AIpv4: "10.0.0.1/31", ZIpv4: "10.0.0.0/31"},
+				testLink("r3", "r1"),
+			},
+		},
+		want: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "10.0.0.0/29", LoopbackIpv4: "192.168.0.0/29"},
+			Nodes: []*topopb.Node{
+				{Name: "r1", LoopbackIpv4: "192.168.0.2/32"},
+				{Name: "r2", LoopbackIpv4: "192.168.0.1/32"},
+				{Name: "r3", LoopbackIpv4: "172.16.0.1/32"},
+			},
+			Links: []*topopb.Link{
+				{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1", AIpv4: "10.0.0.2/31", ZIpv4: "10.0.0.3/31"},
+				{ANode: "r2", AInt: "eth2", ZNode: "r3", ZInt: "eth1", AIpv4: "10.0.0.1/31", ZIpv4: "10.0.0.0/31"},
+				{ANode: "r3", AInt: "eth1", ZNode: "r1", ZInt: "eth1", AIpv4: "10.0.0.4/31", ZIpv4: "10.0.0.5/31"},
+			},
+		},
+	}, {
+		desc: "link pool exhausted",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "10.0.0.0/31"},
+			Links:      []*topopb.Link{testLink("r1", "r2"), testLink("r2", "r3")},
+		},
+		wantErr: "link_ipv4 pool 10.0.0.0/31 is exhausted",
+	}, {
+		desc: "link pool exhausted by reserved addresses",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "10.0.0.0/31"},
+			Links: []*topopb.Link{
+				{ANode: "r1", AInt: "eth1", ZNode: "r2", ZInt: "eth1", AIpv4: "10.0.0.0/31", ZIpv4: "10.0.0.1/31"},
+				testLink("r2", "r3"),
+			},
+		},
+		wantErr: "is exhausted",
+	}, {
+		desc: "loopback pool exhausted",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LoopbackIpv4: "192.168.0.0/31"},
+			Nodes:      []*topopb.Node{{Name: "r1"}, {Name: "r2"}},
+		},
+		wantErr: `node "r2": loopback_ipv4 pool 192.168.0.0/31 is exhausted`,
+	}, {
+		desc: "end of ipv4 address space",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "255.255.255.254/31"},
+			Links:      []*topopb.Link{testLink("r1", "r2"), testLink("r2", "r3")},
+		},
+		wantErr: "link_ipv4 pool 255.255.255.254/31 is exhausted",
+	}, {
+		desc: "end of ipv6 address space",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv6: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"},
+			Links:      []*topopb.Link{testLink("r1", "r2"), testLink("r2", "r3")},
+		},
+		wantErr: "link_ipv6 pool ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127 is exhausted",
+	}, {
+		desc: "invalid pool",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv4: "2001:db8::/64"},
+		},
+		wantErr: "invalid link_ipv4 pool",
+	}, {
+		desc: "pool smaller than a block",
+		in: &topopb.Topology{
+			Addressing: &topopb.Addressing{LinkIpv6: "2001:db8::/128"},
+		},
+		wantErr: "smaller than a /127",
+	}, {
+		desc: "invalid explicit address",
+		in: &topopb.Topology{
+			Nodes: []*topopb.Node{{Name: "r1", LoopbackIpv4: "2001:db8::1/128"}},
+		},
+		wantErr: `node "r1": "2001:db8::1/128" is not an IPv4 address`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := proto.Clone(tt.in).(*topopb.Topology)
+			err := assignAddresses(got)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("assignAddresses() failed: got %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("assignAddresses() failed: %v", err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("assignAddresses() failed: got\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolReserve(t *testing.T) {
+	p, err := newPool("link_ipv4", "10.0.0.0/29", 32, 31)
+	if err != nil {
+		t.Fatalf("newPool() failed: %v", err)
+	}
+	for _, addr := range []string{"10.0.0.3/31", "10.0.0.4/24", "172.16.0.1/31", ""} {
+		if err := p.reserve(addr, 32); err != nil {
+			t.Fatalf("reserve(%q) failed: %v", addr, err)
+		}
+	}
+	if err := p.reserve("10.0.0.1", 32); err == nil {
+		t.Errorf("reserve() of an address without a prefix length succeeded")
+	}
+	var nilPool *pool
+	if err := nilPool.reserve("10.0.0.1/31", 32); err != nil {
+		t.Errorf("reserve() on a nil pool failed: %v", err)
+	}
+	var got []string
+	for {
+		i, err := p.allocate()
+		if err != nil {
+			break
+		}
+		got = append(got, p.addr(i, 0))
+	}
+	want := []string{"10.0.0.0/31", "10.0.0.6/31"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("allocate() failed: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topologyv1 "github.com/h-fam/kne/api/types/v1beta1"
+	"github.com/h-fam/kne/topo/node"
+)
+
+// ApplyResult lists the nodes changed by Apply.
+type ApplyResult struct {
+	Added       []string
+	Removed     []string
+	Recreated   []string
+	Unchanged   []string
+	NewTopology bool // The namespace did not exist and the topology was pushed.
+}
+
+// Created returns the nodes whose pods were created by Apply.
+func (r *ApplyResult) Created() []string {
+	created := append(append([]string{}, r.Added...), r.Recreated...)
+	sort.Strings(created)
+	return created
+}
+
+// linkKey identifies a link by both of its ends.
+type linkKey struct {
+	aNode, aInt, zNode, zInt string
+}
+
+// Apply reconciles the topology in the cluster with the loaded topology.
+// Nodes missing from the cluster are created, nodes no longer in the topology
+// are removed and nodes whose links, config or definition changed have their
+// pods recreated.  Unchanged nodes are left running.  If the topology does
+// not exist in the cluster yet, Apply is equivalent to Push.  The post create
+// steps of added and recreated nodes are not run; see PostCreate.
+func (m *Manager) Apply(ctx context.Context) (*ApplyResult, error) {
+	r := &ApplyResult{}
+	if _, err := m.kClient.CoreV1().Namespaces().Get(ctx, m.tpb.Name, metav1.GetOptions{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err := m.Push(ctx); err != nil {
+			return nil, err
+		}
+		r.NewTopology = true
+		for name := range m.nodes {
+			r.Added = append(r.Added, name)
+		}
+		sort.Strings(r.Added)
+		return r, nil
+	}
+	if _, err := m.storeTopology(ctx); err != nil {
+		return nil, err
+	}
+	existing, err := m.tClient.Topology(m.tpb.Name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topology CRDs: %w", err)
+	}
+	current := map[string]*topologyv1.Topology{}
+	for i := range existing.Items {
+		current[existing.Items[i].Name] = &existing.Items[i]
+	}
+	m.reuseLinkUIDs(current)
+	services, err := m.kClient.CoreV1().Services(m.tpb.Name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if n, ok := m.nodes[strings.TrimPrefix(svc.Name, "service-")]; ok {
+			n.ReuseNodePorts(svc)
+		}
+	}
+
+	// Remove nodes first so their interfaces are released before peers are
+	// rewired.
+	for name := range current {
+		if _, ok := m.nodes[name]; ok {
+			continue
+		}
+		log.Infof("Removing node %q", name)
+		if err := m.removeNode(ctx, name); err != nil {
+			return nil, err
+		}
+		r.Removed = append(r.Removed, name)
+	}
+
+	// As in Push, all topology resources are written before any pod is
+	// created so meshnet can wire up the links of new pods.
+	var mu sync.Mutex
+	linksChanged := map[string]bool{}
+	if err := m.forEachNode(func(n *node.Node) error {
+		name := n.Name()
+		want := m.topologyCR(n)
+		cur, ok := current[name]
+		switch {
+		case !ok:
+			_, err := m.tClient.Topology(m.tpb.Name).Create(ctx, want)
+			return err
+		case reflect.DeepEqual(sortedLinks(cur.Spec.Links), want.Spec.Links):
+			return nil
+		}
+		log.Infof("Links of node %q changed", name)
+		want.ResourceVersion = cur.ResourceVersion
+		if _, err := m.tClient.Topology(m.tpb.Name).Update(ctx, want); err != nil {
+			return err
+		}
+		mu.Lock()
+		linksChanged[name] = true
+		mu.Unlock()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	record := func(list *[]string, name string) {
+		mu.Lock()
+		*list = append(*list, name)
+		mu.Unlock()
+	}
+	if err := m.forEachNode(func(n *node.Node) error {
+		name := n.Name()
+		if _, ok := current[name]; !ok {
+			log.Infof("Adding node %q", name)
+			if err := n.Configure(ctx); err != nil {
+				return err
+			}
+			if err := n.CreateService(ctx); err != nil {
+				return err
+			}
+			if err := n.CreatePod(ctx); err != nil {
+				return err
+			}
+			record(&r.Added, name)
+			return nil
+		}
+		changed, err := m.updateNode(ctx, n, linksChanged[name])
+		if err != nil {
+			return err
+		}
+		if changed {
+			record(&r.Recreated, name)
+		} else {
+			record(&r.Unchanged, name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(r.Added)
+	sort.Strings(r.Recreated)
+	sort.Strings(r.Unchanged)
+	sort.Strings(r.Removed)
+	return r, nil
+}
+
+// updateNode brings an existing node in line with its definition, recreating
+// its pod if anything changed or if changed is already set because its links
+// were updated.  It reports whether the pod was recreated.
+func (m *Manager) updateNode(ctx context.Context, n *node.Node, changed bool) (bool, error) {
+	cfgChanged, err := n.UpdateConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	if cfgChanged {
+		log.Infof("Config of node %q changed", n.Name())
+		changed = true
+	}
+	p, err := n.Pod(ctx)
+	switch {
+	case apierrors.IsNotFound(err):
+		changed = true
+	case err != nil:
+		return false, err
+	case p.Annotations[node.SpecHashAnnotation] != n.SpecHash():
+		log.Infof("Definition of node %q changed", n.Name())
+		if err := n.DeleteService(ctx); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		if err := n.CreateService(ctx); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	if err := n.RecreatePod(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeNode deletes all objects of the node name which is no longer part of
+// the topology.
+func (m *Manager) removeNode(ctx context.Context, name string) error {
+	deletes := []func() error{
+		func() error {
+			return m.kClient.CoreV1().Pods(m.tpb.Name).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+		func() error {
+			return m.kClient.CoreV1().Services(m.tpb.Name).Delete(ctx, fmt.Sprintf("service-%s", name), metav1.DeleteOptions{})
+		},
+		func() error {
+			return m.kClient.CoreV1().ConfigMaps(m.tpb.Name).Delete(ctx, fmt.Sprintf("%s-config", name), metav1.DeleteOptions{})
+		},
+		func() error {
+			return m.tClient.Topology(m.tpb.Name).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+	}
+	for _, d := range deletes {
+		if err := d(); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove node %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// reuseLinkUIDs assigns links which already exist in the cluster their
+// existing UID so unchanged links are not rewired.  New links get UIDs not
+// used by any existing link.
+func (m *Manager) reuseLinkUIDs(current map[string]*topologyv1.Topology) {
+	uids := map[linkKey]int{}
+	next := 0
+	for name, t := range current {
+		for _, l := range t.Spec.Links {
+			uids[linkKey{name, l.LocalIntf, l.PeerPod, l.PeerIntf}] = l.UID
+			if l.UID >= next {
+				next = l.UID + 1
+			}
+		}
+	}
+	for _, l := range m.tpb.Links {
+		// Load already mapped the interfaces successfully.
+		aInt, _ := m.nodes[l.ANode].LinuxIntf(l.AInt)
+		zInt, _ := m.nodes[l.ZNode].LinuxIntf(l.ZInt)
+		uid, ok := uids[linkKey{l.ANode, aInt, l.ZNode, zInt}]
+		if !ok {
+			uid, ok = uids[linkKey{l.ZNode, zInt, l.ANode, aInt}]
+		}
+		if !ok {
+			uid = next
+			next++
+		}
+		m.nodes[l.ANode].Interfaces[aInt].UID = uid
+		m.nodes[l.ZNode].Interfaces[zInt].UID = uid
+	}
+}
+
+func sortedLinks(links []topologyv1.Link) []topologyv1.Link {
+	if len(links) == 0 {
+		return nil
+	}
+	s := append([]topologyv1.Link{}, links...)
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].LocalIntf < s[j].LocalIntf
+	})
+	return s
+}
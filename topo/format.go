@@ -0,0 +1,117 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+// Format is an encoding of a topology file.
+type Format int
+
+const (
+	// FormatText is the protobuf text format (.pb.txt, .textproto).
+	FormatText Format = iota
+	// FormatYAML is YAML using the protobuf JSON mapping (.yaml, .yml).
+	FormatYAML
+	// FormatJSON is the protobuf JSON mapping (.json).
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatYAML:
+		return "yaml"
+	case FormatJSON:
+		return "json"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// ParseFormat returns the Format named s.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "text", "txt", "pbtxt", "textproto", "prototext":
+		return FormatText, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return FormatText, fmt.Errorf("unknown topology format %q", s)
+}
+
+// FormatFromPath returns the Format of fName based on its extension.  Files
+// with unknown extensions are treated as FormatText.
+func FormatFromPath(fName string) Format {
+	switch strings.ToLower(filepath.Ext(fName)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".json":
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// Unmarshal decodes b in format f into t.  JSON and YAML accept both the
+// proto field names (a_node) and their lowerCamelCase JSON names (aNode).
+func Unmarshal(f Format, b []byte, t *topopb.Topology) error {
+	switch f {
+	case FormatYAML:
+		j, err := yaml.YAMLToJSON(b)
+		if err != nil {
+			return err
+		}
+		return protojson.Unmarshal(j, t)
+	case FormatJSON:
+		return protojson.Unmarshal(b, t)
+	}
+	return proto.UnmarshalText(string(b), t)
+}
+
+// Marshal encodes t in format f.  JSON and YAML use the proto field names.
+func Marshal(f Format, t *topopb.Topology) ([]byte, error) {
+	switch f {
+	case FormatYAML, FormatJSON:
+		j, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+		if f == FormatYAML {
+			return yaml.JSONToYAML(j)
+		}
+		// protojson output is deliberately unstable, reindent it so
+		// converted files diff cleanly.
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, j, "", "  "); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+	return []byte(proto.MarshalTextString(t)), nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+// GraphFormat is a diagram format produced by WriteGraph.
+type GraphFormat string
+
+const (
+	GraphDOT     GraphFormat = "dot"
+	GraphMermaid GraphFormat = "mermaid"
+	GraphJSON    GraphFormat = "json"
+)
+
+// Graph is a node and link diagram of a topology.
+type Graph struct {
+	Name  string      `json:"name"`
+	Nodes []GraphNode `json:"nodes"`
+	Links []GraphLink `json:"links"`
+}
+
+// GraphNode is a node in a Graph.  Status is only set if the graph was built
+// with the status of a running topology.
+type GraphNode struct {
+	Name   string       `json:"name"`
+	Type   string       `json:"type"`
+	Status *GraphStatus `json:"status,omitempty"`
+}
+
+// GraphStatus is the status of the pod of a GraphNode.
+type GraphStatus struct {
+	Phase  string `json:"phase"`
+	Ready  bool   `json:"ready"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// GraphLink is a link between the interfaces of two nodes in a Graph.
+type GraphLink struct {
+	ANode string `json:"a_node"`
+	AInt  string `json:"a_int"`
+	ZNode string `json:"z_node"`
+	ZInt  string `json:"z_int"`
+}
+
+// NewGraph returns the diagram of tpb.  If status is non-nil nodes are
+// annotated with their status; nodes missing from status are reported as
+// not found.
+func NewGraph(tpb *topopb.Topology, status map[string]NodeStatus) *Graph {
+	g := &Graph{Name: tpb.Name}
+	for _, n := range tpb.Nodes {
+		gn := GraphNode{
+			Name: n.Name,
+			Type: n.Type.String(),
+		}
+		if status != nil {
+			s, ok := status[n.Name]
+			if !ok {
+				s = podStatus(n.Name, nil)
+			}
+			gn.Status = &GraphStatus{
+				Phase:  string(s.Phase),
+				Ready:  s.Ready,
+				Reason: s.Reason,
+			}
+		}
+		g.Nodes = append(g.Nodes, gn)
+	}
+	for _, l := range tpb.Links {
+		g.Links = append(g.Links, GraphLink{
+			ANode: l.ANode,
+			AInt:  l.AInt,
+			ZNode: l.ZNode,
+			ZInt:  l.ZInt,
+		})
+	}
+	return g
+}
+
+// color returns the fill color of a node with status s.
+func (s *GraphStatus) color() string {
+	switch {
+	case s == nil:
+		return "white"
+	case s.Ready:
+		return "palegreen"
+	case s.Phase == string(corev1.PodRunning), s.Phase == string(corev1.PodPending):
+		return "khaki"
+	case s.Phase == string(corev1.PodFailed):
+		return "lightcoral"
+	default:
+		return "lightgrey"
+	}
+}
+
+// Write writes the graph to w in format f.
+func (g *Graph) Write(w io.Writer, f GraphFormat) error {
+	switch f {
+	case GraphDOT:
+		return g.writeDOT(w)
+	case GraphMermaid:
+		return g.writeMermaid(w)
+	case GraphJSON:
+		b, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+	return fmt.Errorf("unknown graph format %q", f)
+}
+
+func (g *Graph) writeDOT(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "graph %s {\n", strconv.Quote(g.Name))
+	fmt.Fprintf(&b, "  node [shape=box, style=\"rounded,filled\"];\n")
+	for _, n := range g.Nodes {
+		label := n.Name + "\n" + n.Type
+		if n.Status != nil {
+			label += "\n" + n.Status.Phase
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, fillcolor=%q];\n", strconv.Quote(n.Name), strconv.Quote(label), n.Status.color())
+	}
+	for _, l := range g.Links {
+		fmt.Fprintf(&b, "  %s -- %s [taillabel=%s, headlabel=%s];\n",
+			strconv.Quote(l.ANode), strconv.Quote(l.ZNode), strconv.Quote(l.AInt), strconv.Quote(l.ZInt))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidText escapes s for use in a quoted mermaid label.
+func mermaidText(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+func (g *Graph) writeMermaid(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	// Node names are not valid mermaid identifiers in general so nodes are
+	// referred to by index.
+	ids := map[string]string{}
+	id := func(name string) string {
+		if v, ok := ids[name]; ok {
+			return v
+		}
+		ids[name] = fmt.Sprintf("n%d", len(ids))
+		return ids[name]
+	}
+	for _, n := range g.Nodes {
+		label := n.Name + "<br/>" + n.Type
+		if n.Status != nil {
+			label += "<br/>" + n.Status.Phase
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", id(n.Name), mermaidText(label))
+	}
+	for _, l := range g.Links {
+		fmt.Fprintf(&b, "  %s ---|\"%s\"| %s\n", id(l.ANode), mermaidText(l.AInt+" - "+l.ZInt), id(l.ZNode))
+	}
+	for _, n := range g.Nodes {
+		if n.Status != nil {
+			fmt.Fprintf(&b, "  style %s fill:%s\n", id(n.Name), n.Status.color())
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
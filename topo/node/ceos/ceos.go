@@ -1,11 +1,14 @@
 package ceos
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"path"
+	"time"
 
 	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo/node"
-	"google.golang.org/protobuf/proto"
 )
 
 func New(pb *topopb.Node) (node.Interface, error) {
@@ -25,6 +28,59 @@ func (n *Node) Proto() *topopb.Node {
 	return n.pb
 }
 
+// Readiness checks that the EOS CLI is responding, which it only does once
+// the agents have started.
+func (n *Node) Readiness() *node.Readiness {
+	return &node.Readiness{
+		Exec:         []string{"Cli", "-c", "show version"},
+		InitialDelay: 30 * time.Second,
+		Period:       10 * time.Second,
+		Timeout:      10 * time.Second,
+	}
+}
+
+// PostCreate lets LLDP frames through the bridges in the pod so cEOS can
+// discover its peers.
+func (n *Node) PostCreate(ctx context.Context, nn *node.Node) error {
+	return nn.EnableLLDP(ctx)
+}
+
+// flash is the directory EOS refers to as flash:, independent of the config
+// path of the node.
+const flash = "/mnt/flash"
+
+const (
+	// startupConfig is the file in flash EOS boots from.
+	startupConfig = "startup-config"
+	// pushedConfig is the file in flash the pushed config is written to.
+	pushedConfig = "kne-push.cfg"
+)
+
+// ResetConfig replaces the running config with the startup config.
+func (n *Node) ResetConfig(ctx context.Context, nn *node.Node) error {
+	_, err := nn.Output(ctx, []string{"Cli", "-p", "15", "-c", "configure replace flash:" + startupConfig}, nil)
+	return err
+}
+
+// PushConfig replaces the running config with the config read from r.
+func (n *Node) PushConfig(ctx context.Context, nn *node.Node, r io.Reader) error {
+	if err := nn.WriteFile(ctx, path.Join(flash, pushedConfig), r); err != nil {
+		return err
+	}
+	_, err := nn.Output(ctx, []string{"Cli", "-p", "15", "-c", "configure replace flash:" + pushedConfig}, nil)
+	return err
+}
+
+// GetConfig writes the running config to w.
+func (n *Node) GetConfig(ctx context.Context, nn *node.Node, w io.Writer) error {
+	b, err := nn.Output(ctx, []string{"Cli", "-p", "15", "-c", "show running-config"}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
 func defaults(pb *topopb.Node) error {
 	cfg := &topopb.Node{
 		Constraints: map[string]string{
@@ -33,9 +89,8 @@ func defaults(pb *topopb.Node) error {
 		},
 		Services: map[uint32]*topopb.Service{
 			443: {
-				Name:    "ssl",
-				Inside:  443,
-				Outside: node.GetNextPort(),
+				Name:   "ssl",
+				Inside: 443,
 			},
 		},
 		Labels: map[string]string{
@@ -61,16 +116,11 @@ func defaults(pb *topopb.Node) error {
 				"INTFTYPE":                            "eth",
 			},
 			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- Cli", pb.Name),
-			ConfigPath:   "/mnt/flash",
-			ConfigFile:   "startup-config",
+			ConfigPath:   flash,
+			ConfigFile:   startupConfig,
 		},
 	}
-	for _, v := range pb.Services {
-		if v.Outside == 0 {
-			v.Outside = node.GetNextPort()
-		}
-	}
-	proto.Merge(pb, cfg)
+	node.ApplyDefaults(pb, cfg)
 	return nil
 }
 
@@ -0,0 +1,144 @@
+package cevo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
+)
+
+func New(pb *topopb.Node) (node.Interface, error) {
+	if err := defaults(pb); err != nil {
+		return nil, err
+	}
+	return &Node{
+		pb: pb,
+	}, nil
+}
+
+type Node struct {
+	pb *topopb.Node
+}
+
+func (n *Node) Proto() *topopb.Node {
+	return n.pb
+}
+
+// Readiness checks that the Junos CLI is responding, which it only does once
+// the management daemons have started.
+func (n *Node) Readiness() *node.Readiness {
+	return &node.Readiness{
+		Exec:         []string{"cli", "-c", "show version"},
+		InitialDelay: 60 * time.Second,
+		Period:       15 * time.Second,
+		Timeout:      15 * time.Second,
+	}
+}
+
+// licenseFiles returns the sorted names of the config files which are
+// licenses.
+func licenseFiles(pb *topopb.Node) []string {
+	var names []string
+	for name := range pb.Config.GetFiles() {
+		if strings.HasSuffix(name, ".lic") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PostCreate installs the license files of the node.  Licenses are provided
+// as config files with a .lic extension and are mounted in the config path.
+func (n *Node) PostCreate(ctx context.Context, nn *node.Node) error {
+	for _, name := range licenseFiles(n.pb) {
+		cmd := []string{"cli", "-c", "request system license add " + path.Join(n.pb.Config.ConfigPath, name)}
+		if _, err := nn.Output(ctx, cmd, nil); err != nil {
+			return fmt.Errorf("failed to add license %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// loadOverride replaces the configuration with the config file name.
+func loadOverride(ctx context.Context, nn *node.Node, name string) error {
+	_, err := nn.Output(ctx, []string{"cli", "-c", fmt.Sprintf("configure; load override %s; commit and-quit", name)}, nil)
+	return err
+}
+
+// ResetConfig replaces the configuration with the startup config.
+func (n *Node) ResetConfig(ctx context.Context, nn *node.Node) error {
+	if n.pb.Config.GetConfigData() == nil {
+		return fmt.Errorf("node %q has no startup config", n.pb.Name)
+	}
+	return loadOverride(ctx, nn, path.Join(n.pb.Config.ConfigPath, n.pb.Config.ConfigFile))
+}
+
+// pushedConfig is the file the pushed config is written to.
+const pushedConfig = "/var/tmp/kne-push.conf"
+
+// PushConfig replaces the configuration with the config read from r.
+func (n *Node) PushConfig(ctx context.Context, nn *node.Node, r io.Reader) error {
+	if err := nn.WriteFile(ctx, pushedConfig, r); err != nil {
+		return err
+	}
+	return loadOverride(ctx, nn, pushedConfig)
+}
+
+// GetConfig writes the configuration to w.
+func (n *Node) GetConfig(ctx context.Context, nn *node.Node, w io.Writer) error {
+	b, err := nn.Output(ctx, []string{"cli", "-c", "show configuration"}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func defaults(pb *topopb.Node) error {
+	cfg := &topopb.Node{
+		Constraints: map[string]string{
+			"cpu":    "4",
+			"memory": "8Gi",
+		},
+		Services: map[uint32]*topopb.Service{
+			22: {
+				Name:   "ssh",
+				Inside: 22,
+			},
+			443: {
+				Name:   "ssl",
+				Inside: 443,
+			},
+			50051: {
+				Name:   "gnmi",
+				Inside: 50051,
+			},
+		},
+		Labels: map[string]string{
+			"type": topopb.Node_JuniperCEVO.String(),
+		},
+		Config: &topopb.Config{
+			Image:   "cevo:latest",
+			Command: []string{"/entrypoint.sh"},
+			Env: map[string]string{
+				"CEVO": "1",
+			},
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- cli", pb.Name),
+			ConfigPath:   "/home/evo/configdisk",
+			ConfigFile:   "juniper.conf",
+		},
+	}
+	node.ApplyDefaults(pb, cfg)
+	return nil
+}
+
+func init() {
+	node.Register(topopb.Node_JuniperCEVO, New)
+}
@@ -3,8 +3,6 @@ package csr
 import (
 	"fmt"
 
-	"google.golang.org/protobuf/proto"
-
 	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo/node"
 )
@@ -25,18 +23,21 @@ func (n *Node) Proto() *topopb.Node {
 }
 
 func defaults(pb *topopb.Node) error {
-	cfg := &topopb.Config{
-		Image:        "csr:latest",
-		Args:         []string{"--meshnet"},
-		EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
-		ConfigPath:   "/etc",
-		ConfigFile:   "config",
-		Sleep:        10,
+	cfg := &topopb.Node{
+		Constraints: map[string]string{
+			"cpu":    "0.5",
+			"memory": "3Gi",
+		},
+		Config: &topopb.Config{
+			Image:        "csr:latest",
+			Args:         []string{"--meshnet"},
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
+			ConfigPath:   "/etc",
+			ConfigFile:   "config",
+			Sleep:        10,
+		},
 	}
-	proto.Merge(cfg, pb.Config)
-	pb.Config = cfg
-	pb.Constraints["memory"] = "3Gi"
-	pb.Constraints["cpu"] = "0.5"
+	node.ApplyDefaults(pb, cfg)
 	return nil
 }
 
@@ -2,20 +2,35 @@ package cxr
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo/node"
 )
 
 func New(pb *topopb.Node) (node.Interface, error) {
+	if err := defaults(pb); err != nil {
+		return nil, err
+	}
 	return &Node{
 		pb: pb,
 	}, nil
-
 }
 
+// Node is an XRd control-plane node.
+//
+// XRd requires the fs.inotify.max_user_instances and
+// fs.inotify.max_user_watches sysctls of the cluster nodes to be raised
+// (e.g. to 64000 and 64000); these are not namespaced so cannot be set by the
+// pod.
 type Node struct {
 	pb *topopb.Node
 }
@@ -24,19 +39,135 @@ func (n *Node) Proto() *topopb.Node {
 	return n.pb
 }
 
+var (
+	linuxIntf = regexp.MustCompile(`^eth([1-9]\d*)$`)
+	xrIntf    = regexp.MustCompile(`^(?:Gi|GigabitEthernet)0/0/0/(\d+)$`)
+)
+
+// LinuxIntf maps XR interface names like GigabitEthernet0/0/0/0 or Gi0/0/0/0
+// to the Linux interface eth1 backing them.  Linux interface names are
+// accepted as is.
+func (n *Node) LinuxIntf(intf string) (string, error) {
+	if linuxIntf.MatchString(intf) {
+		return intf, nil
+	}
+	m := xrIntf.FindStringSubmatch(intf)
+	if m == nil {
+		return "", fmt.Errorf("invalid XR interface %q, must be GigabitEthernet0/0/0/N, Gi0/0/0/N or ethN", intf)
+	}
+	port, err := strconv.Atoi(m[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid XR interface %q: %w", intf, err)
+	}
+	return fmt.Sprintf("eth%d", port+1), nil
+}
+
+// xrInterfaces returns the XR_INTERFACES value mapping the linked Linux
+// interfaces of nn to XR interfaces.
+func xrInterfaces(nn *node.Node) string {
+	var ports []int
+	for intf := range nn.Interfaces {
+		m := linuxIntf.FindStringSubmatch(intf)
+		if m == nil {
+			continue
+		}
+		port, _ := strconv.Atoi(m[1])
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	var intfs []string
+	for _, p := range ports {
+		intfs = append(intfs, fmt.Sprintf("linux:eth%d,xr_name=Gi0/0/0/%d", p, p-1))
+	}
+	return strings.Join(intfs, ";")
+}
+
+// MutatePod sets the interface mapping and startup config environment
+// variables XRd reads on boot and makes the memory request a limit.
+func (n *Node) MutatePod(nn *node.Node, pod *corev1.Pod) error {
+	c := &pod.Spec.Containers[0]
+	env := []corev1.EnvVar{
+		{Name: "XR_INTERFACES", Value: xrInterfaces(nn)},
+		{Name: "XR_MGMT_INTERFACES", Value: "linux:eth0,xr_name=Mg0/RP0/CPU0/0,chksum"},
+	}
+	if n.pb.Config.GetConfigData() != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "XR_EVERY_BOOT_CONFIG",
+			Value: path.Join(n.pb.Config.ConfigPath, n.pb.Config.ConfigFile),
+		})
+	}
+	// Variables set in the topology take precedence.
+	for _, e := range env {
+		if _, ok := n.pb.Config.Env[e.Name]; !ok {
+			c.Env = append(c.Env, e)
+		}
+	}
+	if c.Resources.Limits == nil {
+		c.Resources.Limits = corev1.ResourceList{}
+	}
+	if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+		c.Resources.Limits[corev1.ResourceMemory] = q
+	}
+	// Huge pages must be requested with equal limits and are used through a
+	// hugetlbfs mount.
+	if v, ok := n.pb.Constraints["hugepages-1Gi"]; ok {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return fmt.Errorf("invalid hugepages-1Gi constraint: %w", err)
+		}
+		c.Resources.Requests["hugepages-1Gi"] = q
+		c.Resources.Limits["hugepages-1Gi"] = q
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "hugepages",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumHugePages},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      "hugepages",
+			MountPath: "/dev/hugepages",
+		})
+	}
+	return nil
+}
+
+// Readiness checks that the XR CLI is responding.
+func (n *Node) Readiness() *node.Readiness {
+	return &node.Readiness{
+		Exec:         []string{"/pkg/bin/xr_cli.sh", "show version"},
+		InitialDelay: 60 * time.Second,
+		Period:       10 * time.Second,
+		Timeout:      10 * time.Second,
+	}
+}
+
 func defaults(pb *topopb.Node) error {
-	cfg := &topopb.Config{
-		Image:        "cxr:latest",
-		Args:         []string{"--meshnet", "--trace"},
-		EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
-		ConfigPath:   "/etc",
-		ConfigFile:   "config",
-		Sleep:        10,
-	}
-	proto.Merge(cfg, pb.Config)
-	pb.Config = cfg
-	pb.Constraints["memory"] = "3Gi"
-	pb.Constraints["cpu"] = "0.5"
+	cfg := &topopb.Node{
+		Constraints: map[string]string{
+			"cpu":    "1",
+			"memory": "2Gi",
+		},
+		Services: map[uint32]*topopb.Service{
+			22: {
+				Name:   "ssh",
+				Inside: 22,
+			},
+			57400: {
+				Name:   "gnmi",
+				Inside: 57400,
+			},
+		},
+		Labels: map[string]string{
+			"type": topopb.Node_CiscoCXR.String(),
+		},
+		Config: &topopb.Config{
+			Image:        "ios-xr/xrd-control-plane:latest",
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- /pkg/bin/xr_cli.sh", pb.Name),
+			ConfigPath:   "/etc/xrd",
+			ConfigFile:   "startup.cfg",
+		},
+	}
+	node.ApplyDefaults(pb, cfg)
 	return nil
 }
 
@@ -0,0 +1,76 @@
+package cxr
+
+import (
+	"testing"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
+)
+
+func TestLinuxIntf(t *testing.T) {
+	tests := []struct {
+		intf    string
+		want    string
+		wantErr bool
+	}{
+		{intf: "GigabitEthernet0/0/0/0", want: "eth1"},
+		{intf: "Gi0/0/0/0", want: "eth1"},
+		{intf: "Gi0/0/0/9", want: "eth10"},
+		{intf: "eth2", want: "eth2"},
+		{intf: "Gi0/0/0", wantErr: true},
+		{intf: "Gi0/0/1/0", wantErr: true},
+		{intf: "TenGigE0/0/0/0", wantErr: true},
+		{intf: "gi0/0/0/0", wantErr: true},
+		{intf: "eth0", wantErr: true},
+		{intf: "Gi0/0/0/99999999999999999999", wantErr: true},
+		{intf: "", wantErr: true},
+	}
+	n, err := New(&topopb.Node{Name: "r1", Type: topopb.Node_CiscoCXR})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.intf, func(t *testing.T) {
+			got, err := n.(*Node).LinuxIntf(tt.intf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LinuxIntf(%q) failed: got error %v, want error %v", tt.intf, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LinuxIntf(%q): got %q, want %q", tt.intf, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXRInterfaces(t *testing.T) {
+	tests := []struct {
+		desc  string
+		intfs []string
+		want  string
+	}{{
+		desc: "no links",
+	}, {
+		desc:  "one link",
+		intfs: []string{"eth1"},
+		want:  "linux:eth1,xr_name=Gi0/0/0/0",
+	}, {
+		desc:  "numeric order",
+		intfs: []string{"eth10", "eth2", "eth1"},
+		want:  "linux:eth1,xr_name=Gi0/0/0/0;linux:eth2,xr_name=Gi0/0/0/1;linux:eth10,xr_name=Gi0/0/0/9",
+	}, {
+		desc:  "non data interfaces",
+		intfs: []string{"eth0", "lo", "eth3"},
+		want:  "linux:eth3,xr_name=Gi0/0/0/2",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			nn := &node.Node{Interfaces: map[string]*node.Link{}}
+			for _, intf := range tt.intfs {
+				nn.Interfaces[intf] = &node.Link{}
+			}
+			if got := xrInterfaces(nn); got != tt.want {
+				t.Errorf("xrInterfaces(): got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,19 +1,22 @@
 package frr
 
 import (
+	"context"
 	"fmt"
-
-	"google.golang.org/protobuf/proto"
+	"io"
+	"path"
 
 	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo/node"
 )
 
 func New(pb *topopb.Node) (node.Interface, error) {
+	if err := defaults(pb); err != nil {
+		return nil, err
+	}
 	return &Node{
 		pb: pb,
 	}, nil
-
 }
 
 type Node struct {
@@ -24,15 +27,49 @@ func (n *Node) Proto() *topopb.Node {
 	return n.pb
 }
 
+// reload applies the differences between the running config and the config
+// file name.
+func reload(ctx context.Context, nn *node.Node, name string) error {
+	_, err := nn.Output(ctx, []string{"/usr/lib/frr/frr-reload.py", "--reload", name}, nil)
+	return err
+}
+
+// ResetConfig reloads the startup config.
+func (n *Node) ResetConfig(ctx context.Context, nn *node.Node) error {
+	return reload(ctx, nn, path.Join(n.pb.Config.ConfigPath, n.pb.Config.ConfigFile))
+}
+
+// pushedConfig is the file the pushed config is written to.
+const pushedConfig = "/tmp/kne-push.conf"
+
+// PushConfig reloads the config read from r.
+func (n *Node) PushConfig(ctx context.Context, nn *node.Node, r io.Reader) error {
+	if err := nn.WriteFile(ctx, pushedConfig, r); err != nil {
+		return err
+	}
+	return reload(ctx, nn, pushedConfig)
+}
+
+// GetConfig writes the running config to w.
+func (n *Node) GetConfig(ctx context.Context, nn *node.Node, w io.Writer) error {
+	b, err := nn.Output(ctx, []string{"vtysh", "-c", "show running-config"}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
 func defaults(pb *topopb.Node) error {
-	cfg := &topopb.Config{
-		Image:        "frrouting/frr:latest",
-		EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
-		ConfigPath:   "/etc/frr",
-		ConfigFile:   "frr.conf",
+	cfg := &topopb.Node{
+		Config: &topopb.Config{
+			Image:        "frrouting/frr:latest",
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
+			ConfigPath:   "/etc/frr",
+			ConfigFile:   "frr.conf",
+		},
 	}
-	proto.Merge(cfg, pb.Config)
-	pb.Config = cfg
+	node.ApplyDefaults(pb, cfg)
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package host
 
 import (
+	"context"
 	"fmt"
 
 	topopb "github.com/h-fam/kne/proto/topo"
@@ -25,15 +26,22 @@ func (n *Node) Proto() *topopb.Node {
 	return n.pb
 }
 
+// PostCreate enables IP forwarding so hosts can route between their links.
+func (n *Node) PostCreate(ctx context.Context, nn *node.Node) error {
+	return nn.EnableIPForwarding(ctx)
+}
+
 func defaults(pb *topopb.Node) error {
-	cfg := &topopb.Config{
-		Image:        "alpine:latest",
-		Command:      []string{"/bin/sh", "-c", "sleep 2000000000000"},
-		EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
-		ConfigPath:   "/etc",
-		ConfigFile:   "config",
+	cfg := &topopb.Node{
+		Config: &topopb.Config{
+			Image:        "alpine:latest",
+			Command:      []string{"/bin/sh", "-c", "sleep 2000000000000"},
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
+			ConfigPath:   "/etc",
+			ConfigFile:   "config",
+		},
 	}
-	pb.Config = cfg
+	node.ApplyDefaults(pb, cfg)
 	return nil
 }
 
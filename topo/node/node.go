@@ -3,21 +3,34 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 
 	topopb "github.com/h-fam/kne/proto/topo"
 )
@@ -26,6 +39,49 @@ type Interface interface {
 	Proto() *topopb.Node
 }
 
+// The following interfaces may optionally be implemented by a node
+// implementation to customize the lifecycle of its nodes.
+
+// PodMutator modifies the pod created for a node before it is created.  The
+// links of the node are available in n.Interfaces.
+type PodMutator interface {
+	MutatePod(n *Node, pod *corev1.Pod) error
+}
+
+// PostCreator configures a node once its pod is running and the node is
+// ready.
+type PostCreator interface {
+	PostCreate(ctx context.Context, n *Node) error
+}
+
+// ReadyChecker reports whether the software in a running pod is ready, e.g.
+// whether the NOS finished booting.
+type ReadyChecker interface {
+	ReadyCheck(ctx context.Context, n *Node) (bool, error)
+}
+
+// ConfigResetter restores the startup config of a running node.
+type ConfigResetter interface {
+	ResetConfig(ctx context.Context, n *Node) error
+}
+
+// ConfigPusher replaces the running config of a node with the config read
+// from r.
+type ConfigPusher interface {
+	PushConfig(ctx context.Context, n *Node, r io.Reader) error
+}
+
+// ConfigGetter writes the running config of a node to w.
+type ConfigGetter interface {
+	GetConfig(ctx context.Context, n *Node, w io.Writer) error
+}
+
+// IntfMapper maps the interface names used by the NOS in the topology, e.g.
+// ethernet-1/1, to the names of the Linux interfaces in the pod.
+type IntfMapper interface {
+	LinuxIntf(intf string) (string, error)
+}
+
 type NewNodeFn func(*topopb.Node) (Interface, error)
 
 var (
@@ -50,6 +106,12 @@ type Node struct {
 	kClient    kubernetes.Interface
 	rCfg       *rest.Config
 	Interfaces map[string]*Link
+	// autoPorts are the services whose node port was assigned by New rather
+	// than set in the topology.
+	autoPorts map[uint32]bool
+	// logCheck tracks the LogLine readiness check across calls of
+	// ReadyCheck.
+	logCheck logCheck
 }
 
 // New creates a new node for use in the k8s cluster.  Configure will push the node to
@@ -59,47 +121,192 @@ func New(namespace string, pb *topopb.Node, kClient kubernetes.Interface, rCfg *
 	if err != nil {
 		return nil, err
 	}
-	return &Node{
+	n := &Node{
 		namespace:  namespace,
 		impl:       impl,
 		rCfg:       rCfg,
 		kClient:    kClient,
 		Interfaces: map[string]*Link{},
-	}, nil
+		autoPorts:  map[uint32]bool{},
+	}
+	n.assignNodePorts()
+	return n, nil
 }
 
-// Configure creates the node on the k8s cluster.
-func (n *Node) Configure(ctx context.Context) error {
+// sortedServices returns the inside ports of the services of pb in order.
+func sortedServices(pb *topopb.Node) []uint32 {
+	var ports []uint32
+	for k := range pb.Services {
+		ports = append(ports, k)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i] < ports[j]
+	})
+	return ports
+}
+
+// assignNodePorts assigns the next node port to all services which have
+// none.  Services are assigned ports in order so the ports only depend on
+// the order of the nodes.
+func (n *Node) assignNodePorts() {
+	pb := n.impl.Proto()
+	for _, k := range sortedServices(pb) {
+		if v := pb.Services[k]; v.Outside == 0 {
+			v.Outside = GetNextPort()
+			n.autoPorts[k] = true
+		}
+	}
+}
+
+// configFiles returns the contents of all config files for the node keyed by
+// file name.
+func (n *Node) configFiles() (map[string][]byte, error) {
 	pb := n.impl.Proto()
-	var data []byte
+	files := map[string][]byte{}
+	if pb.Config.GetConfigData() != nil && pb.Config.ConfigFile == "" {
+		return nil, fmt.Errorf("config_file must be set for node %q", pb.Name)
+	}
+	if len(configFileNames(pb)) != 0 && !path.IsAbs(pb.Config.ConfigPath) {
+		return nil, fmt.Errorf("config_path %q must be an absolute path for node %q", pb.Config.ConfigPath, pb.Name)
+	}
 	switch v := pb.Config.GetConfigData().(type) {
 	case *topopb.Config_File:
-		var err error
-		data, err = ioutil.ReadFile(v.File)
+		data, err := ioutil.ReadFile(v.File)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		files[pb.Config.ConfigFile] = data
 	case *topopb.Config_Data:
-		data = v.Data
+		files[pb.Config.ConfigFile] = v.Data
 	}
-	if data != nil {
-		cm := &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: fmt.Sprintf("%s-config", pb.Name),
-			},
-			Data: map[string]string{
-				pb.Config.ConfigFile: string(data),
-			},
-		}
-		sCM, err := n.kClient.CoreV1().ConfigMaps(n.namespace).Create(ctx, cm, metav1.CreateOptions{})
+	if pb.Config.GetTemplate() && pb.Config.GetConfigData() != nil {
+		data, err := n.renderConfig(files[pb.Config.ConfigFile])
 		if err != nil {
-			return err
+			return nil, err
+		}
+		files[pb.Config.ConfigFile] = data
+	}
+	for name, f := range pb.Config.GetFiles() {
+		switch v := f.GetContents().(type) {
+		case *topopb.File_Path:
+			data, err := ioutil.ReadFile(v.Path)
+			if err != nil {
+				return nil, err
+			}
+			files[name] = data
+		case *topopb.File_Data:
+			files[name] = v.Data
+		default:
+			return nil, fmt.Errorf("config file %q for node %q has no contents", name, pb.Name)
+		}
+	}
+	return files, nil
+}
+
+// configFileNames returns the sorted names of all config files for the node.
+func configFileNames(pb *topopb.Node) []string {
+	var names []string
+	if pb.Config.GetConfigData() != nil {
+		names = append(names, pb.Config.ConfigFile)
+	}
+	for name := range pb.Config.GetFiles() {
+		if pb.Config.GetConfigData() != nil && name == pb.Config.ConfigFile {
+			continue
 		}
-		log.Infof("Server Config Map:\n%v\n", sCM)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// configMapData splits files into text and binary config map data.
+func configMapData(files map[string][]byte) (map[string]string, map[string][]byte) {
+	data := map[string]string{}
+	binaryData := map[string][]byte{}
+	for name, b := range files {
+		if utf8.Valid(b) {
+			data[name] = string(b)
+		} else {
+			binaryData[name] = b
+		}
+	}
+	return data, binaryData
+}
+
+// ConfigMapObject returns the config map holding the config files of the
+// node, or nil if the node has no config files.
+func (n *Node) ConfigMapObject() (*corev1.ConfigMap, error) {
+	files, err := n.configFiles()
+	if err != nil || len(files) == 0 {
+		return nil, err
 	}
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-config", n.Name()),
+			Namespace: n.namespace,
+		},
+	}
+	cm.Data, cm.BinaryData = configMapData(files)
+	return cm, nil
+}
+
+// Configure creates the node on the k8s cluster.
+func (n *Node) Configure(ctx context.Context) error {
+	cm, err := n.ConfigMapObject()
+	if err != nil || cm == nil {
+		return err
+	}
+	sCM, err := n.kClient.CoreV1().ConfigMaps(n.namespace).Create(ctx, cm, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	log.Infof("Server Config Map:\n%v\n", sCM)
 	return nil
 }
 
+// UpdateConfig makes the config map of the node match its config files.  It
+// reports whether the config map was changed.
+func (n *Node) UpdateConfig(ctx context.Context) (bool, error) {
+	name := fmt.Sprintf("%s-config", n.Name())
+	files, err := n.configFiles()
+	if err != nil {
+		return false, err
+	}
+	cm, err := n.kClient.CoreV1().ConfigMaps(n.namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if len(files) == 0 {
+			return false, nil
+		}
+		return true, n.Configure(ctx)
+	case err != nil:
+		return false, err
+	}
+	if len(files) == 0 {
+		return true, n.Delete(ctx)
+	}
+	existing := map[string][]byte{}
+	for k, v := range cm.Data {
+		existing[k] = []byte(v)
+	}
+	for k, v := range cm.BinaryData {
+		existing[k] = v
+	}
+	if reflect.DeepEqual(existing, files) {
+		return false, nil
+	}
+	cm.Data, cm.BinaryData = configMapData(files)
+	if _, err := n.kClient.CoreV1().ConfigMaps(n.namespace).Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return false, err
+	}
+	log.Infof("Updated config map %q", name)
+	return true, nil
+}
+
 // Delete removes the Node from the cluster.
 func (n *Node) Delete(ctx context.Context) error {
 	return n.kClient.CoreV1().ConfigMaps(n.namespace).Delete(ctx, fmt.Sprintf("%s-config", n.Name()), metav1.DeleteOptions{})
@@ -107,30 +314,43 @@ func (n *Node) Delete(ctx context.Context) error {
 
 const (
 	initContainerName = "networkop/init-wait:latest"
+	// configContainerImage copies the config files of a node to the
+	// writable config volume.
+	configContainerImage = "busybox:latest"
 )
 
 func toEnvVar(kv map[string]string) []corev1.EnvVar {
+	var keys []string
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 	var envVar []corev1.EnvVar
-	for k, v := range kv {
+	for _, k := range keys {
 		envVar = append(envVar, corev1.EnvVar{
 			Name:  k,
-			Value: v,
+			Value: kv[k],
 		})
 	}
 	return envVar
 }
 
-func toResourceRequirements(kv map[string]string) corev1.ResourceRequirements {
+func toResourceRequirements(kv map[string]string) (corev1.ResourceRequirements, error) {
 	r := corev1.ResourceRequirements{
 		Requests: map[corev1.ResourceName]resource.Quantity{},
 	}
-	if v, ok := kv["cpu"]; ok {
-		r.Requests["cpu"] = resource.MustParse(v)
-	}
-	if v, ok := kv["memory"]; ok {
-		r.Requests["memory"] = resource.MustParse(v)
+	for _, k := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		v, ok := kv[string(k)]
+		if !ok {
+			continue
+		}
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return r, fmt.Errorf("invalid %s constraint %q: %w", k, v, err)
+		}
+		r.Requests[k] = q
 	}
-	return r
+	return r, nil
 }
 
 var (
@@ -138,17 +358,28 @@ var (
 	gracePeriod int64 = 0
 )
 
-// CreatePod creates the pod for the node.
-func (n *Node) CreatePod(ctx context.Context) error {
+// PodObject returns the pod for the node.
+func (n *Node) PodObject() (*corev1.Pod, error) {
 	pb := n.impl.Proto()
-	log.Infof("Creating Pod:\n %+v", pb)
+	resources, err := toResourceRequirements(pb.Constraints)
+	if err != nil {
+		return nil, fmt.Errorf("node %q: %w", pb.Name, err)
+	}
 	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: pb.Name,
+			Name:      pb.Name,
+			Namespace: n.namespace,
 			Labels: map[string]string{
 				"app":  pb.Name,
 				"topo": n.namespace,
 			},
+			Annotations: map[string]string{
+				SpecHashAnnotation: n.SpecHash(),
+			},
 		},
 		Spec: corev1.PodSpec{
 			InitContainers: []corev1.Container{{
@@ -166,11 +397,12 @@ func (n *Node) CreatePod(ctx context.Context) error {
 				Command:         pb.Config.Command,
 				Args:            pb.Config.Args,
 				Env:             toEnvVar(pb.Config.Env),
-				Resources:       toResourceRequirements(pb.Constraints),
+				Resources:       resources,
 				ImagePullPolicy: "IfNotPresent",
 				SecurityContext: &corev1.SecurityContext{
 					Privileged: &newTrue,
 				},
+				ReadinessProbe: n.Readiness().probe(),
 			}},
 			TerminationGracePeriodSeconds: &gracePeriod,
 			NodeSelector:                  map[string]string{},
@@ -193,7 +425,7 @@ func (n *Node) CreatePod(ctx context.Context) error {
 			},
 		},
 	}
-	if pb.Config.ConfigData != nil {
+	if names := configFileNames(pb); len(names) != 0 {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
 			Name: "startup-config-volume",
 			VolumeSource: corev1.VolumeSource{
@@ -203,7 +435,53 @@ func (n *Node) CreatePod(ctx context.Context) error {
 					},
 				},
 			},
+		}, corev1.Volume{
+			Name: "config-volume",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		// Config map volumes are read-only, so the files are copied to a
+		// writable volume for nodes which save their startup config.
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+			Name:            fmt.Sprintf("config-%s", pb.Name),
+			Image:           configContainerImage,
+			Command:         []string{"sh", "-c", "cp -L /startup-config/* /config/"},
+			ImagePullPolicy: "IfNotPresent",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "startup-config-volume",
+				MountPath: "/startup-config",
+				ReadOnly:  true,
+			}, {
+				Name:      "config-volume",
+				MountPath: "/config",
+			}},
 		})
+		// Each file is mounted individually so the rest of ConfigPath in the
+		// image is left intact.
+		for _, name := range names {
+			pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      "config-volume",
+				MountPath: path.Join(pb.Config.ConfigPath, name),
+				SubPath:   name,
+			})
+		}
+	}
+	if m, ok := n.impl.(PodMutator); ok {
+		if err := m.MutatePod(n, pod); err != nil {
+			return nil, fmt.Errorf("failed to customize pod for %q: %w", pb.Name, err)
+		}
+	}
+	return pod, nil
+}
+
+// CreatePod creates the pod for the node.
+func (n *Node) CreatePod(ctx context.Context) error {
+	pb := n.impl.Proto()
+	log.Infof("Creating Pod:\n %+v", pb)
+	pod, err := n.PodObject()
+	if err != nil {
+		return err
 	}
 	sPod, err := n.kClient.CoreV1().Pods(n.namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
@@ -213,14 +491,62 @@ func (n *Node) CreatePod(ctx context.Context) error {
 	return nil
 }
 
-// CreateService add the service definition for the Node.
-func (n *Node) CreateService(ctx context.Context) error {
+// DeletePod removes the pod for the node.
+func (n *Node) DeletePod(ctx context.Context) error {
+	return n.kClient.CoreV1().Pods(n.namespace).Delete(ctx, n.Name(), metav1.DeleteOptions{})
+}
+
+// RecreatePod deletes the pod for the node, waits for it to be removed and
+// creates it again.
+func (n *Node) RecreatePod(ctx context.Context) error {
+	if err := n.DeletePod(ctx); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := wait.PollImmediateUntil(time.Second, func() (bool, error) {
+		_, err := n.Pod(ctx)
+		switch {
+		case apierrors.IsNotFound(err):
+			return true, nil
+		case err != nil:
+			return false, err
+		}
+		return false, nil
+	}, ctx.Done()); err != nil {
+		return fmt.Errorf("failed waiting for pod %q to be deleted: %w", n.Name(), err)
+	}
+	return n.CreatePod(ctx)
+}
+
+// SpecHashAnnotation is the pod annotation holding the SpecHash of the node
+// the pod was created from.
+const SpecHashAnnotation = "kne/spec-hash"
+
+// SpecHash returns a hash of the node definition used to detect changes to
+// the node since its pod was created.  Automatically assigned node ports are
+// not part of the hash since they depend on the other nodes.
+func (n *Node) SpecHash() string {
+	pb := proto.Clone(n.impl.Proto()).(*topopb.Node)
+	for k := range n.autoPorts {
+		pb.Services[k].Outside = 0
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(pb)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:8])
+}
+
+// ServiceObject returns the service exposing the ports of the node, or nil if
+// the node has no services.
+func (n *Node) ServiceObject() *corev1.Service {
 	pb := n.impl.Proto()
-	var servicePorts []corev1.ServicePort
 	if len(pb.Services) == 0 {
 		return nil
 	}
-	for k, v := range pb.Services {
+	var servicePorts []corev1.ServicePort
+	for _, k := range sortedServices(pb) {
+		v := pb.Services[k]
 		sp := corev1.ServicePort{
 			Name:       fmt.Sprintf("port-%d", k),
 			Protocol:   "TCP",
@@ -230,13 +556,14 @@ func (n *Node) CreateService(ctx context.Context) error {
 		}
 		servicePorts = append(servicePorts, sp)
 	}
-	s := &corev1.Service{
+	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Service",
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: fmt.Sprintf("service-%s", pb.Name),
+			Name:      fmt.Sprintf("service-%s", pb.Name),
+			Namespace: n.namespace,
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: servicePorts,
@@ -246,6 +573,14 @@ func (n *Node) CreateService(ctx context.Context) error {
 			Type: "NodePort",
 		},
 	}
+}
+
+// CreateService add the service definition for the Node.
+func (n *Node) CreateService(ctx context.Context) error {
+	s := n.ServiceObject()
+	if s == nil {
+		return nil
+	}
 	sS, err := n.kClient.CoreV1().Services(n.namespace).Create(ctx, s, metav1.CreateOptions{})
 	if err != nil {
 		return err
@@ -254,6 +589,20 @@ func (n *Node) CreateService(ctx context.Context) error {
 	return nil
 }
 
+// ReuseNodePorts replaces the automatically assigned node ports of the node
+// with the node ports of svc, its existing service, so recreating the
+// service does not change them.
+func (n *Node) ReuseNodePorts(svc *corev1.Service) {
+	pb := n.impl.Proto()
+	for _, sp := range svc.Spec.Ports {
+		for k := range n.autoPorts {
+			if v := pb.Services[k]; int32(v.Inside) == sp.Port && sp.NodePort != 0 {
+				v.Outside = uint32(sp.NodePort)
+			}
+		}
+	}
+}
+
 // DeleteService removes the service definition for the Node.
 func (n *Node) DeleteService(ctx context.Context) error {
 	i := int64(0)
@@ -267,32 +616,134 @@ func (n *Node) DeleteService(ctx context.Context) error {
 
 // Exec will make a connection via spdy transport to the Pod and execute the provided command.
 // It will wire up stdin, stdout, stderr to provided io channels.
+// The connection is closed when ctx is done.
 func (n *Node) Exec(ctx context.Context, cmd []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	return n.stream(ctx, cmd, true, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// Run executes cmd in the Pod without a terminal, so stdout and stderr are
+// kept separate.  If cmd exits with a non-zero status the returned error
+// implements k8s.io/client-go/util/exec.ExitError.
+func (n *Node) Run(ctx context.Context, cmd []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	return n.stream(ctx, cmd, false, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// Terminal executes cmd in the Pod attached to a terminal.  Input is read
+// from stdin and the terminal output written to stdout.  Size changes of the
+// local terminal are read from sizes if it is non-nil.
+func (n *Node) Terminal(ctx context.Context, cmd []string, stdin io.Reader, stdout io.Writer, sizes remotecommand.TerminalSizeQueue) error {
+	return n.stream(ctx, cmd, true, remotecommand.StreamOptions{
+		Stdin:             stdin,
+		Stdout:            stdout,
+		TerminalSizeQueue: sizes,
+	})
+}
+
+// EntryCommand returns the command opening the CLI of the node.  It is taken
+// from the kubectl command in the entry_command of the node config, falling
+// back to sh.
+func (n *Node) EntryCommand() []string {
+	ec := n.impl.Proto().GetConfig().GetEntryCommand()
+	if i := strings.Index(ec, " -- "); i >= 0 {
+		if cmd := strings.Fields(ec[i+len(" -- "):]); len(cmd) != 0 {
+			return cmd
+		}
+	}
+	return []string{"sh"}
+}
+
+// stream executes cmd in the Pod wiring up the streams in sOpts.  If tty is
+// false stdout is passed through unmodified, so it is safe for binary data.
+// The connection to the Pod is closed when ctx is done, which ends the
+// stream; cmd itself may keep running in the Pod.
+func (n *Node) stream(ctx context.Context, cmd []string, tty bool, sOpts remotecommand.StreamOptions) error {
 	req := n.kClient.CoreV1().RESTClient().Post().Resource("pods").Name(n.Name()).Namespace(n.namespace).SubResource("exec")
 	opts := &corev1.PodExecOptions{
-		Command: cmd,
-		Stdin:   true,
-		Stdout:  true,
-		Stderr:  true,
-		TTY:     true,
+		Container: n.Name(),
+		Command:   cmd,
+		Stdin:     sOpts.Stdin != nil,
+		Stdout:    sOpts.Stdout != nil,
+		Stderr:    sOpts.Stderr != nil && !tty,
+		TTY:       tty,
 	}
-	if stdin == nil {
-		opts.Stdin = false
+	if !opts.Stderr {
+		sOpts.Stderr = nil
 	}
 	req.VersionedParams(
 		opts,
 		scheme.ParameterCodec,
 	)
 
-	exec, err := remotecommand.NewSPDYExecutor(n.rCfg, "POST", req.URL())
+	transport, upgrader, err := spdy.RoundTripperFor(n.rCfg)
 	if err != nil {
 		return err
 	}
-	return exec.Stream(remotecommand.StreamOptions{
-		Stdin:  stdin,
-		Stdout: stdout,
+	exec, err := remotecommand.NewSPDYExecutorForTransports(transport, &ctxUpgrader{Upgrader: upgrader, ctx: ctx}, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	sOpts.Tty = tty
+	if err := exec.Stream(sOpts); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// ctxUpgrader closes the connections it creates once ctx is done since
+// remotecommand streams cannot be canceled otherwise.
+type ctxUpgrader struct {
+	spdy.Upgrader
+	ctx context.Context
+}
+
+func (u *ctxUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	c, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-u.ctx.Done():
+			c.Close()
+		case <-c.CloseChan():
+		}
+	}()
+	return c, nil
+}
+
+// Capture runs tcpdump on intf in the Pod and streams the captured packets
+// in pcap format to w.  Capture runs until count packets are captured (if
+// count is non-zero) or the stream is closed.  filter is an optional BPF
+// filter expression.  tcpdump's own diagnostics are written to stderr.  The
+// pod image must provide the tcpdump binary.
+func (n *Node) Capture(ctx context.Context, intf string, count uint32, filter []string, w io.Writer, stderr io.Writer) error {
+	cmd := []string{"tcpdump", "-U", "-n", "-i", intf, "-w", "-"}
+	if count != 0 {
+		cmd = append(cmd, "-c", fmt.Sprintf("%d", count))
+	}
+	cmd = append(cmd, filter...)
+	log.Infof("Capturing on %s:%s: %v", n.Name(), intf, cmd)
+	if stderr == nil {
+		stderr = ioutil.Discard
+	}
+	if err := n.stream(ctx, cmd, false, remotecommand.StreamOptions{
+		Stdout: w,
 		Stderr: stderr,
-	})
+	}); err != nil {
+		return fmt.Errorf("capture on %s:%s failed: %w", n.Name(), intf, err)
+	}
+	return nil
 }
 
 // Status returns the current pod state for Node.
@@ -304,6 +755,116 @@ func (n *Node) Status(ctx context.Context) (corev1.PodPhase, error) {
 	return p.Status.Phase, nil
 }
 
+// HasPostCreate reports whether the node needs to be configured by
+// PostCreate after its pod is running.
+func (n *Node) HasPostCreate() bool {
+	_, ok := n.impl.(PostCreator)
+	return ok
+}
+
+// PostCreate runs the post creation step of the node implementation, if any.
+func (n *Node) PostCreate(ctx context.Context) error {
+	pc, ok := n.impl.(PostCreator)
+	if !ok {
+		return nil
+	}
+	log.Infof("Running post create for node %q", n.Name())
+	if err := pc.PostCreate(ctx, n); err != nil {
+		return fmt.Errorf("post create for node %q failed: %w", n.Name(), err)
+	}
+	return nil
+}
+
+// ResetConfig restores the startup config of the running node.
+func (n *Node) ResetConfig(ctx context.Context) error {
+	rc, ok := n.impl.(ConfigResetter)
+	if !ok {
+		return fmt.Errorf("node %q of type %s does not support resetting its config", n.Name(), n.impl.Proto().Type)
+	}
+	log.Infof("Resetting config of node %q", n.Name())
+	return rc.ResetConfig(ctx, n)
+}
+
+// PushConfig replaces the running config of the node with the config read
+// from r.
+func (n *Node) PushConfig(ctx context.Context, r io.Reader) error {
+	cp, ok := n.impl.(ConfigPusher)
+	if !ok {
+		return fmt.Errorf("node %q of type %s does not support pushing config", n.Name(), n.impl.Proto().Type)
+	}
+	log.Infof("Pushing config to node %q", n.Name())
+	return cp.PushConfig(ctx, n, r)
+}
+
+// GetConfig writes the running config of the node to w.
+func (n *Node) GetConfig(ctx context.Context, w io.Writer) error {
+	cg, ok := n.impl.(ConfigGetter)
+	if !ok {
+		return fmt.Errorf("node %q of type %s does not support getting config", n.Name(), n.impl.Proto().Type)
+	}
+	return cg.GetConfig(ctx, n, w)
+}
+
+// Output runs cmd in the Pod without a terminal and returns its stdout.  If
+// the command fails the error includes its stderr.
+func (n *Node) Output(ctx context.Context, cmd []string, stdin io.Reader) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	if err := n.Run(ctx, cmd, stdin, &stdout, &stderr); err != nil {
+		return nil, fmt.Errorf("%q on node %q failed: %w: %s", strings.Join(cmd, " "), n.Name(), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// WriteFile writes the contents of r to the file name in the Pod.
+func (n *Node) WriteFile(ctx context.Context, name string, r io.Reader) error {
+	_, err := n.Output(ctx, []string{"/bin/sh", "-c", `cat > "$0"`, name}, r)
+	return err
+}
+
+// LinuxIntf returns the name of the Linux interface in the pod for the
+// interface intf of the topology.
+func (n *Node) LinuxIntf(intf string) (string, error) {
+	im, ok := n.impl.(IntfMapper)
+	if !ok {
+		return intf, nil
+	}
+	name, err := im.LinuxIntf(intf)
+	if err != nil {
+		return "", fmt.Errorf("node %q: %w", n.Name(), err)
+	}
+	return name, nil
+}
+
+// ApplyDefaults sets the fields of pb which are not set in the topology to
+// their value in defaults.  Repeated fields such as the command replace the
+// defaults as a whole instead of being appended to them.
+func ApplyDefaults(pb, defaults *topopb.Node) {
+	d := proto.Clone(defaults).(*topopb.Node)
+	if d.Config != nil {
+		if len(pb.GetConfig().GetCommand()) != 0 {
+			d.Config.Command = nil
+		}
+		if len(pb.GetConfig().GetArgs()) != 0 {
+			d.Config.Args = nil
+		}
+	}
+	proto.Merge(d, pb)
+	proto.Reset(pb)
+	proto.Merge(pb, d)
+}
+
+// IntfMapperFor returns a function which maps interface names as LinuxIntf
+// does for the node defined by pb, without creating the node.  pb is not
+// modified.
+func IntfMapperFor(pb *topopb.Node) (func(intf string) (string, error), error) {
+	impl, err := getImpl(proto.Clone(pb).(*topopb.Node))
+	if err != nil {
+		return nil, err
+	}
+	n := &Node{impl: impl}
+	return n.LinuxIntf, nil
+}
+
 // Name returns the name of the node.
 func (n *Node) Name() string {
 	return n.impl.Proto().Name
@@ -316,17 +877,17 @@ func (n *Node) Pod(ctx context.Context) (*corev1.Pod, error) {
 
 var (
 	remountSys = []string{
-		"bin/sh",
+		"/bin/sh",
 		"-c",
 		"mount -o ro,remount /sys; mount -o rw,remount /sys",
 	}
 	getBridge = []string{
-		"bin/sh",
+		"/bin/sh",
 		"-c",
-		"ls /sys/class/net/ | grep br-",
+		"ls /sys/class/net/ | grep br- || true",
 	}
 	enableIPForwarding = []string{
-		"bin/sh",
+		"/bin/sh",
 		"-c",
 		"sysctl -w net.ipv4.ip_forward=1",
 	}
@@ -334,7 +895,7 @@ var (
 
 func enableLLDP(b string) []string {
 	return []string{
-		"bin/sh",
+		"/bin/sh",
 		"-c",
 		fmt.Sprintf("echo 16384 > /sys/class/net/%s/bridge/group_fwd_mask", b),
 	}
@@ -354,7 +915,7 @@ func (n *Node) EnableLLDP(ctx context.Context) error {
 	if err := n.Exec(ctx, getBridge, nil, stdout, stderr); err != nil {
 		return err
 	}
-	bridges := strings.Split(stdout.String(), "\n")
+	bridges := strings.Fields(stdout.String())
 	for _, b := range bridges {
 		stdout.Reset()
 		stderr.Reset()
@@ -379,6 +940,93 @@ func (n *Node) EnableIPForwarding(ctx context.Context) error {
 	return nil
 }
 
+// ValidateImpairment checks that imp contains usable netem values.
+func ValidateImpairment(imp *topopb.Impairment) error {
+	if imp == nil {
+		return nil
+	}
+	for _, p := range []struct {
+		name string
+		v    float32
+	}{
+		{"loss", imp.Loss},
+		{"corruption", imp.Corruption},
+		{"duplication", imp.Duplication},
+	} {
+		if p.v < 0 || p.v > 100 {
+			return fmt.Errorf("invalid impairment: %s %v%% must be between 0 and 100", p.name, p.v)
+		}
+	}
+	if imp.JitterMs != 0 && imp.DelayMs == 0 {
+		return fmt.Errorf("invalid impairment: jitter requires delay")
+	}
+	return nil
+}
+
+func impairmentCmd(intf string, imp *topopb.Impairment) []string {
+	if imp == nil || proto.Equal(imp, &topopb.Impairment{}) {
+		return []string{
+			"/bin/sh",
+			"-c",
+			fmt.Sprintf("tc qdisc del dev %s root 2>/dev/null || true", intf),
+		}
+	}
+	args := []string{"tc", "qdisc", "replace", "dev", intf, "root", "netem"}
+	if imp.DelayMs != 0 {
+		args = append(args, "delay", fmt.Sprintf("%dms", imp.DelayMs))
+		if imp.JitterMs != 0 {
+			args = append(args, fmt.Sprintf("%dms", imp.JitterMs))
+		}
+	}
+	if imp.Loss != 0 {
+		args = append(args, "loss", fmt.Sprintf("%g%%", imp.Loss))
+	}
+	if imp.Corruption != 0 {
+		args = append(args, "corrupt", fmt.Sprintf("%g%%", imp.Corruption))
+	}
+	if imp.Duplication != 0 {
+		args = append(args, "duplicate", fmt.Sprintf("%g%%", imp.Duplication))
+	}
+	if imp.RateKbps != 0 {
+		args = append(args, "rate", fmt.Sprintf("%dkbit", imp.RateKbps))
+	}
+	return args
+}
+
+// SetImpairment applies imp to intf on the pod with tc netem.  A nil or
+// empty impairment removes any existing impairment.  The pod image must
+// provide the tc binary.
+func (n *Node) SetImpairment(ctx context.Context, intf string, imp *topopb.Impairment) error {
+	log.Infof("Setting impairment on %s:%s: %v", n.Name(), intf, imp)
+	stdout := bytes.NewBuffer([]byte{})
+	stderr := bytes.NewBuffer([]byte{})
+	if err := n.Exec(ctx, impairmentCmd(intf, imp), nil, stdout, stderr); err != nil {
+		return fmt.Errorf("failed to set impairment on %s:%s: %w: %s", n.Name(), intf, err, stdout.String()+stderr.String())
+	}
+	log.Infof("stdout:\n%s\nstderr:\n%s", stdout.String(), stderr.String())
+	return nil
+}
+
+func linkStateCmd(intf string, up bool) []string {
+	state := "down"
+	if up {
+		state = "up"
+	}
+	return []string{"ip", "link", "set", "dev", intf, state}
+}
+
+// SetLinkState sets the admin state of intf on the pod.
+func (n *Node) SetLinkState(ctx context.Context, intf string, up bool) error {
+	log.Infof("Setting link state on %s:%s: up=%v", n.Name(), intf, up)
+	stdout := bytes.NewBuffer([]byte{})
+	stderr := bytes.NewBuffer([]byte{})
+	if err := n.Exec(ctx, linkStateCmd(intf, up), nil, stdout, stderr); err != nil {
+		return fmt.Errorf("failed to set link state on %s:%s: %w: %s", n.Name(), intf, err, stdout.String()+stderr.String())
+	}
+	log.Infof("stdout:\n%s\nstderr:\n%s", stdout.String(), stderr.String())
+	return nil
+}
+
 func getImpl(pb *topopb.Node) (Interface, error) {
 	mu.Lock()
 	defer mu.Unlock()
@@ -389,9 +1037,15 @@ func getImpl(pb *topopb.Node) (Interface, error) {
 	return fn(pb)
 }
 
+// Link is one end of a link as seen from a node.  Proto refers to the Linux
+// interfaces in the pods with the node as the A side.  Intf and PeerIntf
+// are the interface names of both ends as written in the topology.
 type Link struct {
-	UID   int
-	Proto *topopb.Link
+	UID      int
+	Proto    *topopb.Link
+	Intf     string
+	PeerIntf string
+	Index    int // Index of the link in the links of the topology.
 }
 
 var (
@@ -406,3 +1060,13 @@ func GetNextPort() uint32 {
 	nextPort++
 	return p
 }
+
+// ReservePort keeps GetNextPort from returning p or any port below it, e.g.
+// for node ports of topologies created by an earlier process.
+func ReservePort(p uint32) {
+	muPort.Lock()
+	defer muPort.Unlock()
+	if p >= nextPort {
+		nextPort = p + 1
+	}
+}
@@ -0,0 +1,133 @@
+package otg
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
+)
+
+func New(pb *topopb.Node) (node.Interface, error) {
+	if err := defaults(pb); err != nil {
+		return nil, err
+	}
+	return &Node{
+		pb: pb,
+	}, nil
+}
+
+// Node is an Ixia-c Open Traffic Generator.  The pod runs the controller,
+// which serves the OTG API, and one traffic engine per linked interface.
+// The engine for interface ethN listens on port 5554+N, so the location of
+// the test port on eth1 is localhost:5555.
+//
+// The engine image is set by config.sidecar_image.
+type Node struct {
+	pb *topopb.Node
+}
+
+func (n *Node) Proto() *topopb.Node {
+	return n.pb
+}
+
+// basePort is the listen port of the engine for eth0, which is never used
+// for traffic.
+const basePort = 5554
+
+var linuxIntf = regexp.MustCompile(`^eth([1-9]\d*)$`)
+
+// enginePort returns the listen port of the engine for interface intf.
+func enginePort(intf string) (int, error) {
+	m := linuxIntf.FindStringSubmatch(intf)
+	if m == nil {
+		return 0, fmt.Errorf("invalid OTG interface %q, must be ethN", intf)
+	}
+	port, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, err
+	}
+	return basePort + port, nil
+}
+
+// LinuxIntf only accepts Linux interface names since engine ports are
+// located by port number.
+func (n *Node) LinuxIntf(intf string) (string, error) {
+	if _, err := enginePort(intf); err != nil {
+		return "", err
+	}
+	return intf, nil
+}
+
+// MutatePod adds a traffic engine container for each linked interface.
+func (n *Node) MutatePod(nn *node.Node, pod *corev1.Pod) error {
+	var intfs []string
+	for intf := range nn.Interfaces {
+		intfs = append(intfs, intf)
+	}
+	sort.Strings(intfs)
+	privileged := true
+	for _, intf := range intfs {
+		port, err := enginePort(intf)
+		if err != nil {
+			return err
+		}
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name:  fmt.Sprintf("engine-%s", intf),
+			Image: n.pb.Config.SidecarImage,
+			Env: []corev1.EnvVar{
+				{Name: "ARG_IFACE_LIST", Value: "virtual@af_packet," + intf},
+				{Name: "OPT_LISTEN_PORT", Value: strconv.Itoa(port)},
+				{Name: "OPT_NO_HUGEPAGES", Value: "Yes"},
+			},
+			ImagePullPolicy: "IfNotPresent",
+			SecurityContext: &corev1.SecurityContext{
+				Privileged: &privileged,
+			},
+		})
+	}
+	return nil
+}
+
+// Readiness waits for the controller to serve the OTG API.
+func (n *Node) Readiness() *node.Readiness {
+	return &node.Readiness{
+		TCPPort: 443,
+		Period:  5 * time.Second,
+	}
+}
+
+func defaults(pb *topopb.Node) error {
+	cfg := &topopb.Node{
+		Services: map[uint32]*topopb.Service{
+			443: {
+				Name:   "https",
+				Inside: 443,
+			},
+			40051: {
+				Name:   "grpc",
+				Inside: 40051,
+			},
+		},
+		Labels: map[string]string{
+			"type": topopb.Node_OTG.String(),
+		},
+		Config: &topopb.Config{
+			Image:        "ghcr.io/open-traffic-generator/ixia-c-controller:latest",
+			Args:         []string{"--accept-eula"},
+			SidecarImage: "ghcr.io/open-traffic-generator/ixia-c-traffic-engine:latest",
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
+		},
+	}
+	node.ApplyDefaults(pb, cfg)
+	return nil
+}
+
+func init() {
+	node.Register(topopb.Node_OTG, New)
+}
@@ -3,8 +3,6 @@ package quagga
 import (
 	"fmt"
 
-	"google.golang.org/protobuf/proto"
-
 	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo/node"
 )
@@ -25,14 +23,15 @@ func (n *Node) Proto() *topopb.Node {
 }
 
 func defaults(pb *topopb.Node) error {
-	cfg := &topopb.Config{
-		Image:        "networkop/qrtr:latest",
-		EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
-		ConfigPath:   "/etc/quagga",
-		ConfigFile:   "Quagga.conf",
+	cfg := &topopb.Node{
+		Config: &topopb.Config{
+			Image:        "networkop/qrtr:latest",
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
+			ConfigPath:   "/etc/quagga",
+			ConfigFile:   "Quagga.conf",
+		},
 	}
-	proto.Merge(cfg, pb.Config)
-	pb.Config = cfg
+	node.ApplyDefaults(pb, cfg)
 	return nil
 }
 
@@ -0,0 +1,154 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Readiness describes how to check that the software in a node has booted.
+// Exec and TCPPort are checked by a readiness probe on the node's pod.
+// LogLine is checked by ReadyCheck since pods cannot probe their own logs.
+type Readiness struct {
+	Exec    []string       // Command which succeeds once the node is ready.
+	TCPPort int            // Port which accepts connections once the node is ready.
+	LogLine *regexp.Regexp // Matches a container log line written once the node is ready.
+
+	InitialDelay time.Duration // Time after the container starts before the first check.
+	Period       time.Duration // Time between checks.
+	Timeout      time.Duration // Time after which a single check fails.
+}
+
+// ReadinessProber is implemented by node implementations which declare how
+// their readiness is checked.
+type ReadinessProber interface {
+	Readiness() *Readiness
+}
+
+// Readiness returns how the readiness of the node is checked, or nil if the
+// node is ready once its containers are running.
+func (n *Node) Readiness() *Readiness {
+	rp, ok := n.impl.(ReadinessProber)
+	if !ok {
+		return nil
+	}
+	return rp.Readiness()
+}
+
+func seconds(d time.Duration) int32 {
+	return int32(d / time.Second)
+}
+
+// probe returns the pod readiness probe for r, or nil if r has no check a
+// probe can perform.
+func (r *Readiness) probe() *corev1.Probe {
+	if r == nil {
+		return nil
+	}
+	p := &corev1.Probe{
+		InitialDelaySeconds: seconds(r.InitialDelay),
+		PeriodSeconds:       seconds(r.Period),
+		TimeoutSeconds:      seconds(r.Timeout),
+	}
+	switch {
+	case len(r.Exec) != 0:
+		p.Exec = &corev1.ExecAction{Command: r.Exec}
+	case r.TCPPort != 0:
+		p.TCPSocket = &corev1.TCPSocketAction{Port: intstr.FromInt(r.TCPPort)}
+	default:
+		return nil
+	}
+	return p
+}
+
+// HasReadyCheck reports whether the node is checked by ReadyCheck in
+// addition to the readiness of its pod.
+func (n *Node) HasReadyCheck() bool {
+	if _, ok := n.impl.(ReadyChecker); ok {
+		return true
+	}
+	return n.Readiness().logLine() != nil
+}
+
+func (r *Readiness) logLine() *regexp.Regexp {
+	if r == nil {
+		return nil
+	}
+	return r.LogLine
+}
+
+// logOverlap is how far before the previous fetch the container log is read
+// again, to cover lines written during the fetch and clock skew between the
+// client and the cluster.
+const logOverlap = time.Minute
+
+// logCheck is the state of the LogLine readiness check of a node.
+type logCheck struct {
+	mu    sync.Mutex
+	since *metav1.Time // Start of the previous fetch, nil before the first.
+	seen  bool         // Whether the log line was matched.
+}
+
+// ReadyCheck reports whether the node is ready according to its
+// implementation.  This is in addition to the readiness of the pod, which
+// includes any Exec or TCPPort check.  Nodes without a ReadyChecker or a
+// LogLine readiness check are ready once their pod is.  If the node is not
+// ready, reason describes why.  An error means the node will never be ready.
+func (n *Node) ReadyCheck(ctx context.Context) (ready bool, reason string, err error) {
+	if rc, ok := n.impl.(ReadyChecker); ok {
+		ready, err := rc.ReadyCheck(ctx, n)
+		if err != nil || ready {
+			return ready, "", err
+		}
+		return false, "waiting for node to finish booting", nil
+	}
+	re := n.Readiness().logLine()
+	if re == nil {
+		return true, "", nil
+	}
+	c := &n.logCheck
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen {
+		return true, "", nil
+	}
+	// Only the log written since the previous check is fetched since the
+	// log of a booting node may be large.
+	start := metav1.NewTime(time.Now().Add(-logOverlap))
+	b, err := n.kClient.CoreV1().Pods(n.namespace).GetLogs(n.Name(), &corev1.PodLogOptions{
+		Container: n.Name(),
+		SinceTime: c.since,
+	}).Do(ctx).Raw()
+	if err != nil {
+		// The container may be restarting; try again on the next check.
+		log.Debugf("Failed to get logs of node %q: %v", n.Name(), err)
+		return false, fmt.Sprintf("failed to get logs: %v", err), nil
+	}
+	c.since = &start
+	if !re.Match(b) {
+		return false, fmt.Sprintf("waiting for log line %q", re), nil
+	}
+	c.seen = true
+	return true, "", nil
+}
@@ -0,0 +1,91 @@
+package sonic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
+)
+
+func New(pb *topopb.Node) (node.Interface, error) {
+	if err := defaults(pb); err != nil {
+		return nil, err
+	}
+	return &Node{
+		pb: pb,
+	}, nil
+}
+
+type Node struct {
+	pb *topopb.Node
+}
+
+func (n *Node) Proto() *topopb.Node {
+	return n.pb
+}
+
+// lanesPerPort is the number of lanes of each front panel port in the
+// SONiC-VS port config.  Port EthernetN uses Linux interface eth(N/4+1).
+const lanesPerPort = 4
+
+var (
+	linuxIntf = regexp.MustCompile(`^eth([1-9]\d*)$`)
+	sonicIntf = regexp.MustCompile(`^Ethernet(\d+)$`)
+)
+
+// LinuxIntf maps SONiC port names like Ethernet4 to the Linux interface
+// eth2 backing the port.  Linux interface names are accepted as is.
+func (n *Node) LinuxIntf(intf string) (string, error) {
+	if linuxIntf.MatchString(intf) {
+		return intf, nil
+	}
+	m := sonicIntf.FindStringSubmatch(intf)
+	if m == nil {
+		return "", fmt.Errorf("invalid SONiC interface %q, must be EthernetN or ethN", intf)
+	}
+	port, err := strconv.Atoi(m[1])
+	if err != nil || port%lanesPerPort != 0 {
+		return "", fmt.Errorf("invalid SONiC interface %q, port must be a multiple of %d", intf, lanesPerPort)
+	}
+	return fmt.Sprintf("eth%d", port/lanesPerPort+1), nil
+}
+
+// Readiness waits for the ports to be initialized by the orchestration
+// agent.
+func (n *Node) Readiness() *node.Readiness {
+	return &node.Readiness{
+		Exec:         []string{"/bin/sh", "-c", "redis-cli -n 0 exists PORT_TABLE:PortInitDone | grep -q 1"},
+		InitialDelay: 20 * time.Second,
+		Period:       5 * time.Second,
+		Timeout:      5 * time.Second,
+	}
+}
+
+func defaults(pb *topopb.Node) error {
+	cfg := &topopb.Node{
+		Constraints: map[string]string{
+			"cpu":    "1",
+			"memory": "2Gi",
+		},
+		Labels: map[string]string{
+			"type": topopb.Node_SonicVS.String(),
+		},
+		// The image entrypoint starts supervisord which runs the SONiC
+		// services.
+		Config: &topopb.Config{
+			Image:        "docker-sonic-vs:latest",
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- bash", pb.Name),
+			ConfigPath:   "/etc/sonic",
+			ConfigFile:   "config_db.json",
+		},
+	}
+	node.ApplyDefaults(pb, cfg)
+	return nil
+}
+
+func init() {
+	node.Register(topopb.Node_SonicVS, New)
+}
@@ -0,0 +1,43 @@
+package sonic
+
+import (
+	"testing"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+func TestLinuxIntf(t *testing.T) {
+	tests := []struct {
+		intf    string
+		want    string
+		wantErr bool
+	}{
+		{intf: "Ethernet0", want: "eth1"},
+		{intf: "Ethernet4", want: "eth2"},
+		{intf: "Ethernet124", want: "eth32"},
+		{intf: "eth3", want: "eth3"},
+		{intf: "Ethernet1", wantErr: true},
+		{intf: "Ethernet6", wantErr: true},
+		{intf: "Ethernet", wantErr: true},
+		{intf: "Ethernet-4", wantErr: true},
+		{intf: "ethernet4", wantErr: true},
+		{intf: "eth0", wantErr: true},
+		{intf: "Ethernet99999999999999999999", wantErr: true},
+		{intf: "", wantErr: true},
+	}
+	n, err := New(&topopb.Node{Name: "r1", Type: topopb.Node_SonicVS})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.intf, func(t *testing.T) {
+			got, err := n.(*Node).LinuxIntf(tt.intf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LinuxIntf(%q) failed: got error %v, want error %v", tt.intf, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LinuxIntf(%q): got %q, want %q", tt.intf, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package srl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
+)
+
+func New(pb *topopb.Node) (node.Interface, error) {
+	if err := defaults(pb); err != nil {
+		return nil, err
+	}
+	return &Node{
+		pb: pb,
+	}, nil
+}
+
+type Node struct {
+	pb *topopb.Node
+}
+
+func (n *Node) Proto() *topopb.Node {
+	return n.pb
+}
+
+var (
+	// linuxIntf matches the Linux interface names of front panel ports, e.g.
+	// e1-1 or e1-3-1 for a breakout port.
+	linuxIntf = regexp.MustCompile(`^e\d+-\d+(-\d+)?$`)
+	// srlIntf matches the SR Linux names of front panel ports, e.g.
+	// ethernet-1/1 or ethernet-1/3/1 for a breakout port.
+	srlIntf = regexp.MustCompile(`^ethernet-(\d+/\d+(/\d+)?)$`)
+)
+
+// LinuxIntf maps SR Linux interface names like ethernet-1/1 to the Linux
+// interface e1-1 SR Linux uses for the port.  Linux interface names are
+// accepted as is.
+func (n *Node) LinuxIntf(intf string) (string, error) {
+	if linuxIntf.MatchString(intf) {
+		return intf, nil
+	}
+	m := srlIntf.FindStringSubmatch(intf)
+	if m == nil {
+		return "", fmt.Errorf("invalid SR Linux interface %q, must be ethernet-X/Y or eX-Y", intf)
+	}
+	return "e" + strings.ReplaceAll(m[1], "/", "-"), nil
+}
+
+// Readiness waits for the gNMI server, which SR Linux starts once the
+// management server application is running.
+func (n *Node) Readiness() *node.Readiness {
+	return &node.Readiness{
+		TCPPort:      57400,
+		InitialDelay: 10 * time.Second,
+		Period:       5 * time.Second,
+	}
+}
+
+func defaults(pb *topopb.Node) error {
+	cfg := &topopb.Node{
+		Constraints: map[string]string{
+			"cpu":    "0.5",
+			"memory": "2Gi",
+		},
+		Services: map[uint32]*topopb.Service{
+			22: {
+				Name:   "ssh",
+				Inside: 22,
+			},
+			57400: {
+				Name:   "gnmi",
+				Inside: 57400,
+			},
+		},
+		Labels: map[string]string{
+			"type": topopb.Node_NokiaSRL.String(),
+		},
+		// SR Linux requires a privileged container, which all node pods are.
+		Config: &topopb.Config{
+			Image: "ghcr.io/nokia/srlinux:latest",
+			Command: []string{
+				"/tini",
+				"--",
+				"fixuid",
+				"-q",
+				"/entrypoint.sh",
+				"sudo",
+				"bash",
+				"-c",
+				"touch /.dockerenv && /opt/srlinux/bin/sr_linux",
+			},
+			Env: map[string]string{
+				"SRLINUX": "1",
+			},
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sr_cli", pb.Name),
+			ConfigPath:   "/etc/opt/srlinux",
+			ConfigFile:   "config.json",
+		},
+	}
+	node.ApplyDefaults(pb, cfg)
+	return nil
+}
+
+func init() {
+	node.Register(topopb.Node_NokiaSRL, New)
+}
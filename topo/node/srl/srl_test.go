@@ -0,0 +1,43 @@
+package srl
+
+import (
+	"testing"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+func TestLinuxIntf(t *testing.T) {
+	tests := []struct {
+		intf    string
+		want    string
+		wantErr bool
+	}{
+		{intf: "ethernet-1/1", want: "e1-1"},
+		{intf: "ethernet-1/12", want: "e1-12"},
+		{intf: "ethernet-2/3/1", want: "e2-3-1"},
+		{intf: "e1-1", want: "e1-1"},
+		{intf: "e1-3-2", want: "e1-3-2"},
+		{intf: "ethernet-1", wantErr: true},
+		{intf: "ethernet-1/", wantErr: true},
+		{intf: "ethernet-1/1/1/1", wantErr: true},
+		{intf: "Ethernet-1/1", wantErr: true},
+		{intf: "e1", wantErr: true},
+		{intf: "eth1", wantErr: true},
+		{intf: "", wantErr: true},
+	}
+	n, err := New(&topopb.Node{Name: "r1", Type: topopb.Node_NokiaSRL})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.intf, func(t *testing.T) {
+			got, err := n.(*Node).LinuxIntf(tt.intf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LinuxIntf(%q) failed: got error %v, want error %v", tt.intf, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LinuxIntf(%q): got %q, want %q", tt.intf, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"text/template"
+)
+
+// TemplateData is the data startup config templates are executed with, e.g.
+//
+//	hostname {{.Name}}
+//	{{range .Interfaces}}
+//	interface {{.Name}}
+//	   description to {{.PeerNode}}:{{.PeerIntf}}
+//	   ip address {{.IPv4}}
+//	{{end}}
+//
+// Addresses are in CIDR notation and empty if not assigned.  Templates may
+// use the functions ip, prefixlen and netmask to split them, e.g.
+// {{ip .LoopbackIPv4}}.
+type TemplateData struct {
+	Name         string
+	Type         string // Node type, e.g. AristaCEOS.
+	Labels       map[string]string
+	LoopbackIPv4 string
+	LoopbackIPv6 string
+	Interfaces   []*TemplateIntf // In the order of the links in the topology.
+}
+
+// TemplateIntf is a connected interface of a node in TemplateData.
+type TemplateIntf struct {
+	Name      string // Interface name as written in the topology.
+	LinuxIntf string // Linux interface in the pod.
+	PeerNode  string
+	PeerIntf  string // Peer interface name as written in the topology.
+	UID       int
+	IPv4      string
+	IPv6      string
+	PeerIPv4  string
+	PeerIPv6  string
+}
+
+// templateFuncs are the functions available to startup config templates.
+var templateFuncs = template.FuncMap{
+	// ip returns the address of a CIDR, e.g. 10.0.0.1 for 10.0.0.1/31.
+	"ip": func(cidr string) (string, error) {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		return ip.String(), nil
+	},
+	// prefixlen returns the prefix length of a CIDR, e.g. 31 for 10.0.0.1/31.
+	"prefixlen": func(cidr string) (int, error) {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return 0, err
+		}
+		ones, _ := n.Mask.Size()
+		return ones, nil
+	},
+	// netmask returns the netmask of an IPv4 CIDR, e.g. 255.255.255.254 for
+	// 10.0.0.1/31.
+	"netmask": func(cidr string) (string, error) {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		if len(n.Mask) != net.IPv4len {
+			return "", fmt.Errorf("%q is not an IPv4 address", cidr)
+		}
+		return net.IP(n.Mask).String(), nil
+	},
+}
+
+// TemplateData returns the data the startup config template of n is executed
+// with.
+func (n *Node) TemplateData() *TemplateData {
+	pb := n.impl.Proto()
+	d := &TemplateData{
+		Name:         pb.Name,
+		Type:         pb.Type.String(),
+		Labels:       pb.Labels,
+		LoopbackIPv4: pb.LoopbackIpv4,
+		LoopbackIPv6: pb.LoopbackIpv6,
+	}
+	var links []*Link
+	for _, l := range n.Interfaces {
+		links = append(links, l)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Index < links[j].Index
+	})
+	for _, l := range links {
+		d.Interfaces = append(d.Interfaces, &TemplateIntf{
+			Name:      l.Intf,
+			LinuxIntf: l.Proto.AInt,
+			PeerNode:  l.Proto.ZNode,
+			PeerIntf:  l.PeerIntf,
+			UID:       l.UID,
+			IPv4:      l.Proto.AIpv4,
+			IPv6:      l.Proto.AIpv6,
+			PeerIPv4:  l.Proto.ZIpv4,
+			PeerIPv6:  l.Proto.ZIpv6,
+		})
+	}
+	return d
+}
+
+// ParseTemplate parses a startup config template.  Referencing a missing
+// label is an error when the template is executed.
+func ParseTemplate(name string, b []byte) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Funcs(templateFuncs).Parse(string(b))
+}
+
+// renderConfig executes the startup config template b for the node.
+func (n *Node) renderConfig(b []byte) ([]byte, error) {
+	t, err := ParseTemplate(n.impl.Proto().Config.ConfigFile, b)
+	if err != nil {
+		return nil, fmt.Errorf("config template for node %q: %w", n.Name(), err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, n.TemplateData()); err != nil {
+		return nil, fmt.Errorf("config template for node %q: %w", n.Name(), err)
+	}
+	return buf.Bytes(), nil
+}
@@ -3,8 +3,8 @@ package unknown
 import (
 	log "github.com/sirupsen/logrus"
 
-	topopb "github.com/hfam/kne/proto/topo"
-	"github.com/hfam/kne/topo/node"
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
 )
 
 func New(pb *topopb.Node) (node.Interface, error) {
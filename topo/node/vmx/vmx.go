@@ -2,14 +2,17 @@ package vmx
 
 import (
 	"fmt"
-
-	"google.golang.org/protobuf/proto"
+	"regexp"
+	"time"
 
 	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo/node"
 )
 
 func New(pb *topopb.Node) (node.Interface, error) {
+	if err := defaults(pb); err != nil {
+		return nil, err
+	}
 	return &Node{
 		pb: pb,
 	}, nil
@@ -24,19 +27,34 @@ func (n *Node) Proto() *topopb.Node {
 	return n.pb
 }
 
+// startupComplete is logged by the vMX launcher once the VM has booted.
+var startupComplete = regexp.MustCompile(`Startup complete`)
+
+// Readiness waits for the VM in the pod to finish booting, which takes
+// several minutes.
+func (n *Node) Readiness() *node.Readiness {
+	return &node.Readiness{
+		LogLine: startupComplete,
+		Period:  30 * time.Second,
+	}
+}
+
 func defaults(pb *topopb.Node) error {
-	cfg := &topopb.Config{
-		Image:        "vmx:latest",
-		Args:         []string{"--meshnet, --trace"},
-		EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
-		ConfigPath:   "/etc/quagga",
-		ConfigFile:   "Quagga.conf",
-		Sleep:        10,
+	cfg := &topopb.Node{
+		Constraints: map[string]string{
+			"cpu":    "1",
+			"memory": "5Gi",
+		},
+		Config: &topopb.Config{
+			Image:        "vmx:latest",
+			Args:         []string{"--meshnet", "--trace"},
+			EntryCommand: fmt.Sprintf("kubectl exec -it %s -- sh", pb.Name),
+			ConfigPath:   "/config",
+			ConfigFile:   "startup-config.cfg",
+			Sleep:        10,
+		},
 	}
-	proto.Merge(cfg, pb.Config)
-	pb.Config = cfg
-	pb.Constraints["memory"] = "5Gi"
-	pb.Constraints["cpu"] = "1"
+	node.ApplyDefaults(pb, cfg)
 	return nil
 }
 
@@ -0,0 +1,257 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a location in a topology file.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// positions maps field paths such as "links[2].a_node" to the location they
+// are defined at in a topology file.
+type positions map[string]Position
+
+// lookup returns the position of path, or of its closest parent which has a
+// known position.
+func (p positions) lookup(path string) (Position, bool) {
+	for path != "" {
+		if pos, ok := p[path]; ok {
+			return pos, true
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return Position{}, false
+}
+
+// filePositions indexes the field positions of b in format f.  Indexing is
+// best effort; an unparsable file results in an empty index.
+func filePositions(f Format, b []byte) positions {
+	p := positions{}
+	switch f {
+	case FormatYAML, FormatJSON:
+		var n yaml.Node
+		if err := yaml.Unmarshal(b, &n); err == nil {
+			p.addYAML(&n, "")
+		}
+	default:
+		s := &textScanner{src: []rune(string(b)), line: 1, col: 1}
+		s.message(p, "", 0)
+	}
+	return p
+}
+
+func (p positions) addYAML(n *yaml.Node, prefix string) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			p.addYAML(c, prefix)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			path := prefix + snakeCase(k.Value)
+			p[path] = Position{Line: k.Line, Column: k.Column}
+			p.addYAML(v, path+".")
+		}
+	case yaml.SequenceNode:
+		base := strings.TrimSuffix(prefix, ".")
+		for i, c := range n.Content {
+			path := fmt.Sprintf("%s[%d]", base, i)
+			p[path] = Position{Line: c.Line, Column: c.Column}
+			p.addYAML(c, path+".")
+		}
+	}
+}
+
+// snakeCase converts protobuf JSON names (aNode) to field names (a_node).
+func snakeCase(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// textScanner is a minimal tokenizer for the protobuf text format which only
+// tracks where fields are defined.
+type textScanner struct {
+	src       []rune
+	i         int
+	line, col int
+}
+
+type textToken struct {
+	s   string
+	pos Position
+}
+
+func (s *textScanner) next() rune {
+	r := s.src[s.i]
+	s.i++
+	if r == '\n' {
+		s.line++
+		s.col = 1
+	} else {
+		s.col++
+	}
+	return r
+}
+
+func (s *textScanner) skipSpace() {
+	for s.i < len(s.src) {
+		switch r := s.src[s.i]; {
+		case r == '#':
+			for s.i < len(s.src) && s.src[s.i] != '\n' {
+				s.next()
+			}
+		case unicode.IsSpace(r):
+			s.next()
+		default:
+			return
+		}
+	}
+}
+
+// token returns the next token, or an empty token at the end of input.
+func (s *textScanner) token() textToken {
+	s.skipSpace()
+	t := textToken{pos: Position{Line: s.line, Column: s.col}}
+	if s.i >= len(s.src) {
+		return t
+	}
+	start := s.i
+	switch r := s.next(); {
+	case r == '"' || r == '\'':
+		for s.i < len(s.src) {
+			c := s.next()
+			if c == '\\' && s.i < len(s.src) {
+				s.next()
+				continue
+			}
+			if c == r || c == '\n' {
+				break
+			}
+		}
+	case strings.ContainsRune("{}<>[]:,;", r):
+	default:
+		for s.i < len(s.src) {
+			c := s.src[s.i]
+			if unicode.IsSpace(c) || strings.ContainsRune("{}<>[]:,;#\"'", c) {
+				break
+			}
+			s.next()
+		}
+	}
+	t.s = string(s.src[start:s.i])
+	return t
+}
+
+func (s *textScanner) peek() string {
+	i, line, col := s.i, s.line, s.col
+	t := s.token()
+	s.i, s.line, s.col = i, line, col
+	return t.s
+}
+
+func closing(open string) string {
+	if open == "<" {
+		return ">"
+	}
+	return "}"
+}
+
+// message records the fields of a message until end (0 for the top level).
+// Fields of nested messages are recorded under both path[i] and, for the
+// first occurrence, path so singular and repeated fields can be looked up
+// the same way.
+func (s *textScanner) message(p positions, prefix string, end rune) {
+	counts := map[string]int{}
+	value := func(name string, t textToken) {
+		path := prefix + name
+		if _, ok := p[path]; !ok {
+			p[path] = t.pos
+		}
+		idx := counts[name]
+		counts[name]++
+		ePath := fmt.Sprintf("%s[%d]", path, idx)
+		p[ePath] = t.pos
+		if t.s != "{" && t.s != "<" {
+			return
+		}
+		sub := positions{}
+		s.message(sub, "", []rune(closing(t.s))[0])
+		for k, v := range sub {
+			p[ePath+"."+k] = v
+			if _, ok := p[path+"."+k]; !ok && idx == 0 {
+				p[path+"."+k] = v
+			}
+		}
+	}
+	for {
+		t := s.token()
+		switch {
+		case t.s == "":
+			return
+		case end != 0 && t.s == string(end):
+			return
+		case t.s == "," || t.s == ";":
+			continue
+		}
+		name := t.s
+		if s.peek() == ":" {
+			s.token()
+		}
+		v := s.token()
+		if v.s != "[" {
+			value(name, textToken{s: v.s, pos: t.pos})
+			// Adjacent string literals are concatenated.
+			for strings.HasPrefix(s.peek(), `"`) || strings.HasPrefix(s.peek(), `'`) {
+				s.token()
+			}
+			continue
+		}
+		for {
+			e := s.token()
+			if e.s == "]" || e.s == "" {
+				break
+			}
+			if e.s == "," {
+				continue
+			}
+			value(name, e)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"testing"
+)
+
+const textTopo = `name: "t" # comment with "quotes" and { braces
+nodes: {
+  name: "r1"
+  labels { key: "a" value: "b" }
+}
+nodes <
+  name: 'r2'
+>
+links: {
+  a_node: "r1" a_int: "eth1"
+  z_node: "r2"
+  z_int: "eth\"1"
+}
+links {
+  a_node: "r1"
+  a_int: "eth" "2"
+}
+`
+
+const yamlTopo = `name: t
+nodes:
+  - name: r1
+  - name: r2
+links:
+  - aNode: r1
+    aInt: eth1
+`
+
+const jsonTopo = `{
+  "name": "t",
+  "nodes": [
+    {"name": "r1"},
+    {"name": "r2"}
+  ],
+  "links": [{"aNode": "r1", "zNode": "r2"}]
+}`
+
+func TestFilePositions(t *testing.T) {
+	tests := []struct {
+		desc   string
+		format Format
+		in     string
+		want   map[string]Position
+	}{{
+		desc:   "text",
+		format: FormatText,
+		in:     textTopo,
+		want: map[string]Position{
+			"name":                  {1, 1},
+			"nodes":                 {2, 1},
+			"nodes[0]":              {2, 1},
+			"nodes[0].name":         {3, 3},
+			"nodes[0].labels":       {4, 3},
+			"nodes[0].labels.key":   {4, 12},
+			"nodes[1]":              {6, 1},
+			"nodes[1].name":         {7, 3},
+			"nodes.name":            {3, 3},
+			"links[0].a_node":       {10, 3},
+			"links[0].a_int":        {10, 16},
+			"links[0].z_node":       {11, 3},
+			"links[0].z_int":        {12, 3},
+			"links[1]":              {14, 1},
+			"links[1].a_node":       {15, 3},
+			"links[1].a_int":        {16, 3},
+			"nodes[0].labels[0]":    {4, 3},
+			"nodes[0].labels.value": {4, 21},
+		},
+	}, {
+		desc:   "text list",
+		format: FormatText,
+		in:     "name: \"t\"\nnodes: [\n  {name: \"r1\"},\n  {name: \"r2\"}\n]\n",
+		want: map[string]Position{
+			"nodes[0]":      {3, 3},
+			"nodes[0].name": {3, 4},
+			"nodes[1]":      {4, 3},
+			"nodes[1].name": {4, 4},
+		},
+	}, {
+		desc:   "yaml",
+		format: FormatYAML,
+		in:     yamlTopo,
+		want: map[string]Position{
+			"name":            {1, 1},
+			"nodes":           {2, 1},
+			"nodes[0]":        {3, 5},
+			"nodes[0].name":   {3, 5},
+			"nodes[1].name":   {4, 5},
+			"links[0].a_node": {6, 5},
+			"links[0].a_int":  {7, 5},
+		},
+	}, {
+		desc:   "json",
+		format: FormatJSON,
+		in:     jsonTopo,
+		want: map[string]Position{
+			"name":            {2, 3},
+			"nodes[0].name":   {4, 6},
+			"nodes[1].name":   {5, 6},
+			"links[0].a_node": {7, 14},
+			"links[0].z_node": {7, 29},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := filePositions(tt.format, []byte(tt.in))
+			for path, want := range tt.want {
+				if got, ok := p[path]; !ok || got != want {
+					t.Errorf("filePositions() position of %q: got %v (found %v), want %v", path, got, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFilePositionsInvalid(t *testing.T) {
+	tests := []struct {
+		desc   string
+		format Format
+		in     string
+	}{
+		{"unterminated string", FormatText, `name: "t`},
+		{"unterminated message", FormatText, "nodes: {\n  name: \"r1\"\n"},
+		{"unterminated list", FormatText, "nodes: [ { name: \"r1\" }"},
+		{"dangling field", FormatText, "name:"},
+		{"yaml", FormatYAML, "name: [t"},
+		{"json", FormatJSON, `{"name": `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Indexing is best effort and must not panic on invalid input.
+			filePositions(tt.format, []byte(tt.in))
+		})
+	}
+}
+
+func TestPositionsLookup(t *testing.T) {
+	p := positions{
+		"nodes":               {2, 1},
+		"nodes[1]":            {6, 1},
+		"nodes[1].name":       {7, 3},
+		"links[0]":            {9, 1},
+		"links[0].impairment": {10, 3},
+	}
+	tests := []struct {
+		path   string
+		want   Position
+		wantOK bool
+	}{
+		{"nodes[1].name", Position{7, 3}, true},
+		{"nodes[1].config.file", Position{6, 1}, true},
+		{"nodes[3].name", Position{2, 1}, true},
+		{"links[0].impairment.delay", Position{10, 3}, true},
+		{"links[0].a_node", Position{9, 1}, true},
+		{"name", Position{}, false},
+		{"addressing.link_ipv4", Position{}, false},
+		{"", Position{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := p.lookup(tt.path)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("lookup(%q): got %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"name", "name"},
+		{"aNode", "a_node"},
+		{"loopbackIpv4", "loopback_ipv4"},
+		{"a_node", "a_node"},
+	}
+	for _, tt := range tests {
+		if got := snakeCase(tt.in); got != tt.want {
+			t.Errorf("snakeCase(%q): got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
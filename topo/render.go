@@ -0,0 +1,69 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+// Render returns the Kubernetes objects Push creates for tpb without
+// contacting a cluster.  Objects are returned in the order they must be
+// created: the namespace, the config map storing the topology, the meshnet
+// topology resources of all nodes and then the config map, service and pod
+// of each node.
+func Render(tpb *topopb.Topology) ([]runtime.Object, error) {
+	m := newManager(tpb)
+	if err := m.Load(context.Background()); err != nil {
+		return nil, err
+	}
+	return m.Render()
+}
+
+// Render returns the Kubernetes objects Push creates for the loaded
+// topology.  See the package level Render.
+func (m *Manager) Render() ([]runtime.Object, error) {
+	names := m.NodeNames()
+	cm, err := m.topologyConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	objs := []runtime.Object{m.namespace(), cm}
+	for _, name := range names {
+		objs = append(objs, m.topologyCR(m.nodes[name]))
+	}
+	for _, name := range names {
+		n := m.nodes[name]
+		cm, err := n.ConfigMapObject()
+		if err != nil {
+			return nil, err
+		}
+		if cm != nil {
+			objs = append(objs, cm)
+		}
+		if s := n.ServiceObject(); s != nil {
+			objs = append(objs, s)
+		}
+		pod, err := n.PodObject()
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, pod)
+	}
+	return objs, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+func TestRenderUserConfigOverridesDefaults(t *testing.T) {
+	for typ := range topopb.Node_Type_name {
+		typ := topopb.Node_Type(typ)
+		if typ == topopb.Node_Unknown {
+			continue
+		}
+		t.Run(typ.String(), func(t *testing.T) {
+			tpb := &topopb.Topology{
+				Name: "test",
+				Nodes: []*topopb.Node{{
+					Name: "r1",
+					Type: typ,
+					Config: &topopb.Config{
+						Image:      "custom:1.0",
+						Command:    []string{"/bin/custom"},
+						ConfigPath: "/etc/x",
+						ConfigFile: "custom.cfg",
+						ConfigData: &topopb.Config_Data{Data: []byte("hostname r1")},
+					},
+				}},
+			}
+			objs, err := Render(tpb)
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+			var pod *corev1.Pod
+			for _, o := range objs {
+				if p, ok := o.(*corev1.Pod); ok {
+					pod = p
+				}
+			}
+			if pod == nil {
+				t.Fatalf("Render() returned no pod")
+			}
+			c := pod.Spec.Containers[0]
+			if c.Image != "custom:1.0" {
+				t.Errorf("Render() image: got %q, want %q", c.Image, "custom:1.0")
+			}
+			if want := []string{"/bin/custom"}; !reflect.DeepEqual(c.Command, want) {
+				t.Errorf("Render() command: got %q, want %q", c.Command, want)
+			}
+			var mounts []string
+			for _, m := range c.VolumeMounts {
+				mounts = append(mounts, m.MountPath)
+				if m.MountPath == "/etc/x/custom.cfg" {
+					return
+				}
+			}
+			t.Errorf("Render() mounts: got %q, want /etc/x/custom.cfg", mounts)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
+)
+
+// NodeFilter selects nodes of a topology.  A node matches if its type is one
+// of Types and it has all of Labels.  Empty fields match all nodes.
+type NodeFilter struct {
+	Types  []topopb.Node_Type
+	Labels map[string]string
+}
+
+// Match reports whether pb is selected by f.
+func (f NodeFilter) Match(pb *topopb.Node) bool {
+	if len(f.Types) != 0 {
+		found := false
+		for _, t := range f.Types {
+			if pb.Type == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for k, v := range f.Labels {
+		if pb.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// CommandResult is the outcome of running a command on a node.
+type CommandResult struct {
+	Node   string
+	Stdout []byte
+	Stderr []byte
+	Err    error // Non-nil if the command could not be run or failed.
+}
+
+// RunCommand runs cmd on every node matched by f, using up to the
+// manager's number of workers concurrently.  The results are sorted by node
+// name.
+func (m *Manager) RunCommand(ctx context.Context, f NodeFilter, cmd []string) []*CommandResult {
+	var (
+		mu       sync.Mutex
+		results  []*CommandResult
+		selected = map[string]bool{}
+	)
+	for _, pb := range m.tpb.Nodes {
+		selected[pb.Name] = f.Match(pb)
+	}
+	// Failures are reported in the results so errors are never returned.
+	m.forEachNode(func(n *node.Node) error {
+		if !selected[n.Name()] {
+			return nil
+		}
+		var stdout, stderr bytes.Buffer
+		err := n.Run(ctx, cmd, nil, &stdout, &stderr)
+		mu.Lock()
+		results = append(results, &CommandResult{
+			Node:   n.Name(),
+			Stdout: stdout.Bytes(),
+			Stderr: stderr.Bytes(),
+			Err:    err,
+		})
+		mu.Unlock()
+		return nil
+	})
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Node < results[j].Node
+	})
+	return results
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+)
+
+const (
+	// topologyConfigMap is the config map in the namespace of a topology
+	// holding the loaded topology, including node defaults and assigned
+	// addresses, so the topology can be recovered from the cluster.
+	topologyConfigMap = "kne-topology"
+	// topologyLabel labels topology config maps with the topology name.
+	topologyLabel = "kne/topology"
+	topologyKey   = "topology.json"
+)
+
+// topologyConfigMap returns the config map storing the loaded topology.
+func (m *Manager) topologyConfigMap() (*corev1.ConfigMap, error) {
+	b, err := Marshal(FormatJSON, m.tpb)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      topologyConfigMap,
+			Namespace: m.tpb.Name,
+			Labels: map[string]string{
+				topologyLabel: m.tpb.Name,
+			},
+		},
+		Data: map[string]string{
+			topologyKey: string(b),
+		},
+	}, nil
+}
+
+// storeTopology creates or updates the config map storing the loaded
+// topology.  It reports whether the config map was created.
+func (m *Manager) storeTopology(ctx context.Context) (bool, error) {
+	cm, err := m.topologyConfigMap()
+	if err != nil {
+		return false, err
+	}
+	cms := m.kClient.CoreV1().ConfigMaps(m.tpb.Name)
+	cur, err := cms.Get(ctx, topologyConfigMap, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := cms.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return false, fmt.Errorf("failed to store topology: %w", err)
+		}
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to store topology: %w", err)
+	}
+	cm.ResourceVersion = cur.ResourceVersion
+	if _, err := cms.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return false, fmt.Errorf("failed to store topology: %w", err)
+	}
+	return false, nil
+}
+
+// Topologies returns the topologies stored in the cluster by Push and
+// Apply, e.g. to recover the topologies created before a restart.
+func Topologies(ctx context.Context, kubecfg string) ([]*topopb.Topology, error) {
+	rCfg, err := restConfig(kubecfg)
+	if err != nil {
+		return nil, err
+	}
+	kClient, err := kubernetes.NewForConfig(rCfg)
+	if err != nil {
+		return nil, err
+	}
+	cms, err := kClient.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{
+		LabelSelector: topologyLabel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var tpbs []*topopb.Topology
+	for _, cm := range cms.Items {
+		if cm.Name != topologyConfigMap {
+			continue
+		}
+		tpb := &topopb.Topology{}
+		if err := Unmarshal(FormatJSON, []byte(cm.Data[topologyKey]), tpb); err != nil {
+			return nil, fmt.Errorf("invalid topology stored in namespace %q: %w", cm.Namespace, err)
+		}
+		tpbs = append(tpbs, tpb)
+	}
+	return tpbs, nil
+}
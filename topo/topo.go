@@ -17,14 +17,22 @@ package topo
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -34,14 +42,18 @@ import (
 	topopb "github.com/h-fam/kne/proto/topo"
 	"github.com/h-fam/kne/topo/node"
 
-	_ "github.com/hfam/kne/topo/node/ceos"
-	_ "github.com/hfam/kne/topo/node/csr"
-	_ "github.com/hfam/kne/topo/node/cxr"
-	_ "github.com/hfam/kne/topo/node/frr"
-	_ "github.com/hfam/kne/topo/node/host"
-	_ "github.com/hfam/kne/topo/node/quagga"
-	_ "github.com/hfam/kne/topo/node/unknown"
-
+	_ "github.com/h-fam/kne/topo/node/ceos"
+	_ "github.com/h-fam/kne/topo/node/cevo"
+	_ "github.com/h-fam/kne/topo/node/csr"
+	_ "github.com/h-fam/kne/topo/node/cxr"
+	_ "github.com/h-fam/kne/topo/node/frr"
+	_ "github.com/h-fam/kne/topo/node/host"
+	_ "github.com/h-fam/kne/topo/node/otg"
+	_ "github.com/h-fam/kne/topo/node/quagga"
+	_ "github.com/h-fam/kne/topo/node/sonic"
+	_ "github.com/h-fam/kne/topo/node/srl"
+	_ "github.com/h-fam/kne/topo/node/unknown"
+	_ "github.com/h-fam/kne/topo/node/vmx"
 )
 
 var (
@@ -60,20 +72,41 @@ type Manager struct {
 	tpb     *topopb.Topology
 	nodes   map[string]*node.Node
 	links   map[string]*node.Link
+
+	keepOnFailure bool
+	workers       int
+}
+
+// DefaultWorkers is the default number of nodes created or deleted
+// concurrently.
+const DefaultWorkers = 10
+
+// Option configures a Manager.
+type Option func(m *Manager)
+
+// WithKeepOnFailure keeps the objects created by a failed Push in the
+// cluster instead of deleting them, which is useful for debugging.
+func WithKeepOnFailure(keep bool) Option {
+	return func(m *Manager) {
+		m.keepOnFailure = keep
+	}
+}
+
+// WithWorkers sets the number of nodes created or deleted concurrently.
+func WithWorkers(n int) Option {
+	return func(m *Manager) {
+		if n > 0 {
+			m.workers = n
+		}
+	}
 }
 
 // New creates a new topology manager based on the provided kubecfg and topology.
-func New(kubecfg string, tpb *topopb.Topology) (*Manager, error) {
+func New(kubecfg string, tpb *topopb.Topology, opts ...Option) (*Manager, error) {
 	log.Infof("Creating manager for: %s", tpb.Name)
-	// use the current context in kubeconfig try in-cluster first if not fallback to kubeconfig
-	log.Infof("Trying in-cluster configuration")
-	rCfg, err := rest.InClusterConfig()
+	rCfg, err := restConfig(kubecfg)
 	if err != nil {
-		log.Infof("Falling back to kubeconfig: %q", kubecfg)
-		rCfg, err = clientcmd.BuildConfigFromFlags("", kubecfg)
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 	// create the clientset
 	kClient, err := kubernetes.NewForConfig(rCfg)
@@ -85,18 +118,44 @@ func New(kubecfg string, tpb *topopb.Topology) (*Manager, error) {
 		return nil, err
 	}
 
-	return &Manager{
-		kClient: kClient,
-		tClient: tClient,
-		rCfg:    rCfg,
+	m := newManager(tpb, opts...)
+	m.kClient = kClient
+	m.tClient = tClient
+	m.rCfg = rCfg
+	return m, nil
+}
+
+// restConfig returns the in-cluster configuration or, outside a cluster,
+// the current context of kubecfg.
+func restConfig(kubecfg string) (*rest.Config, error) {
+	log.Infof("Trying in-cluster configuration")
+	rCfg, err := rest.InClusterConfig()
+	if err == nil {
+		return rCfg, nil
+	}
+	log.Infof("Falling back to kubeconfig: %q", kubecfg)
+	return clientcmd.BuildConfigFromFlags("", kubecfg)
+}
+
+// newManager returns a manager for tpb which is not connected to a cluster.
+func newManager(tpb *topopb.Topology, opts ...Option) *Manager {
+	m := &Manager{
 		tpb:     tpb,
 		nodes:   map[string]*node.Node{},
 		links:   map[string]*node.Link{},
-	}, nil
+		workers: DefaultWorkers,
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
 }
 
 // Load creates an instance of the managed topology.
 func (m *Manager) Load(ctx context.Context) error {
+	if err := assignAddresses(m.tpb); err != nil {
+		return fmt.Errorf("failed to assign addresses: %w", err)
+	}
 	for _, n := range m.tpb.Nodes {
 		log.Infof("Adding Node: %s:%s", n.Name, n.Type)
 		nn, err := node.New(m.tpb.Name, n, m.kClient, m.rCfg)
@@ -106,7 +165,7 @@ func (m *Manager) Load(ctx context.Context) error {
 		m.nodes[n.Name] = nn
 	}
 	uid := 0
-	for _, l := range m.tpb.Links {
+	for i, l := range m.tpb.Links {
 		log.Infof("Adding Link: %s:%s %s:%s", l.ANode, l.AInt, l.ZNode, l.ZInt)
 		sNode, ok := m.nodes[l.ANode]
 		if !ok {
@@ -116,24 +175,45 @@ func (m *Manager) Load(ctx context.Context) error {
 		if !ok {
 			return fmt.Errorf("invalid topology: missing node %q", l.ZNode)
 		}
-		if _, ok := sNode.Interfaces[l.AInt]; ok {
+		if err := node.ValidateImpairment(l.Impairment); err != nil {
+			return fmt.Errorf("link %s:%s %s:%s: %w", l.ANode, l.AInt, l.ZNode, l.ZInt, err)
+		}
+		// The links of the nodes refer to the Linux interfaces in the pods.
+		sl := proto.Clone(l).(*topopb.Link)
+		var err error
+		if sl.AInt, err = sNode.LinuxIntf(l.AInt); err != nil {
+			return err
+		}
+		if sl.ZInt, err = dNode.LinuxIntf(l.ZInt); err != nil {
+			return err
+		}
+		if _, ok := sNode.Interfaces[sl.AInt]; ok {
 			return fmt.Errorf("interface %s:%s already connected", l.ANode, l.AInt)
 		}
-		if _, ok := dNode.Interfaces[l.ZInt]; ok {
+		if _, ok := dNode.Interfaces[sl.ZInt]; ok {
 			return fmt.Errorf("interface %s:%s already connected", l.ZNode, l.ZInt)
 		}
 		link := &node.Link{
-			UID:   uid,
-			Proto: l,
+			UID:      uid,
+			Proto:    sl,
+			Intf:     l.AInt,
+			PeerIntf: l.ZInt,
+			Index:    i,
 		}
-		sNode.Interfaces[l.AInt] = link
-		dl := proto.Clone(l).(*topopb.Link)
+		sNode.Interfaces[sl.AInt] = link
+		dl := proto.Clone(sl).(*topopb.Link)
 		dl.AInt, dl.ZInt = dl.ZInt, dl.AInt
 		dl.ANode, dl.ZNode = dl.ZNode, dl.ANode
+		dl.AIpv4, dl.ZIpv4 = dl.ZIpv4, dl.AIpv4
+		dl.AIpv6, dl.ZIpv6 = dl.ZIpv6, dl.AIpv6
 		dLink := &node.Link{
-			Proto: dl,
+			UID:      uid,
+			Proto:    dl,
+			Intf:     l.ZInt,
+			PeerIntf: l.AInt,
+			Index:    i,
 		}
-		dNode.Interfaces[l.ZInt] = dLink
+		dNode.Interfaces[sl.ZInt] = dLink
 		uid++
 	}
 	return nil
@@ -163,85 +243,473 @@ func (m *Manager) Topology(ctx context.Context) error {
 	return nil
 }
 
-// Push pushes the current topology to k8s.
+// topologyCR returns the meshnet Topology resource describing the links of n.
+func (m *Manager) topologyCR(n *node.Node) *topologyv1.Topology {
+	t := &topologyv1.Topology{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Topology",
+			APIVersion: topologyv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n.Name(),
+			Namespace: m.tpb.Name,
+		},
+		Spec: topologyv1.TopologySpec{},
+	}
+	var links []topologyv1.Link
+	for _, intf := range n.Interfaces {
+		link := topologyv1.Link{
+			LocalIntf: intf.Proto.AInt,
+			LocalIP:   linkIP(intf.Proto.AIpv4, intf.Proto.AIpv6),
+			PeerIntf:  intf.Proto.ZInt,
+			PeerIP:    linkIP(intf.Proto.ZIpv4, intf.Proto.ZIpv6),
+			PeerPod:   intf.Proto.ZNode,
+			UID:       intf.UID,
+		}
+		links = append(links, link)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].LocalIntf < links[j].LocalIntf
+	})
+	t.Spec.Links = links
+	return t
+}
+
+// forEachNode calls fn for every node using up to m.workers goroutines.  The
+// errors of all nodes are returned as an aggregate.
+func (m *Manager) forEachNode(fn func(n *node.Node) error) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, m.workers)
+	)
+	for _, n := range m.nodes {
+		n := n
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := fn(n); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("node %q: %w", n.Name(), err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.NewAggregate(errs)
+}
+
+// undoStack records how to remove the objects created by Push so a failed
+// push can be rolled back.  It is safe for concurrent use.
+type undoStack struct {
+	mu      sync.Mutex
+	entries []undoEntry
+}
+
+type undoEntry struct {
+	desc string
+	fn   func(context.Context) error
+}
+
+func (u *undoStack) push(desc string, fn func(context.Context) error) {
+	u.mu.Lock()
+	u.entries = append(u.entries, undoEntry{desc: desc, fn: fn})
+	u.mu.Unlock()
+}
+
+// run removes the recorded objects in reverse order of creation.  Objects
+// which no longer exist are ignored.
+func (u *undoStack) run(ctx context.Context) []error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	var errs []error
+	for i := len(u.entries) - 1; i >= 0; i-- {
+		e := u.entries[i]
+		log.Infof("Rolling back %s", e.desc)
+		if err := e.fn(ctx); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", e.desc, err))
+		}
+	}
+	return errs
+}
+
+// Push pushes the current topology to k8s.  If any object fails to be
+// created, all objects created so far are deleted again unless the manager
+// was created WithKeepOnFailure.  Push does not wait for the nodes to be
+// ready, callers run PostCreate once it returns to apply link impairments and
+// run the post create steps of the nodes.
 func (m *Manager) Push(ctx context.Context) error {
+	undo := &undoStack{}
+	err := m.push(ctx, undo)
+	if err == nil {
+		return nil
+	}
+	if m.keepOnFailure {
+		log.Warnf("Push of topology %q failed, keeping %d created objects", m.tpb.Name, len(undo.entries))
+		return err
+	}
+	// Roll back even if ctx is what caused the failure.
+	errs := append([]error{err}, undo.run(context.Background())...)
+	return errors.NewAggregate(errs)
+}
+
+// namespace returns the namespace holding all objects of the topology.
+func (m *Manager) namespace() *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: m.tpb.Name,
+		},
+	}
+}
+
+func (m *Manager) push(ctx context.Context, undo *undoStack) error {
 	if _, err := m.kClient.CoreV1().Namespaces().Get(ctx, m.tpb.Name, metav1.GetOptions{}); err != nil {
 		log.Infof("Creating namespace for topology: %q", m.tpb.Name)
-		ns := &corev1.Namespace{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: m.tpb.Name,
-			},
-		}
-		sNs, err := m.kClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		sNs, err := m.kClient.CoreV1().Namespaces().Create(ctx, m.namespace(), metav1.CreateOptions{})
 		if err != nil {
 			return err
 		}
+		undo.push(fmt.Sprintf("namespace %q", m.tpb.Name), func(ctx context.Context) error {
+			return m.kClient.CoreV1().Namespaces().Delete(ctx, m.tpb.Name, metav1.DeleteOptions{})
+		})
 		log.Infof("Server Namespace: %+v", sNs)
 	}
+	created, err := m.storeTopology(ctx)
+	if err != nil {
+		return err
+	}
+	if created {
+		undo.push("stored topology", func(ctx context.Context) error {
+			return m.kClient.CoreV1().ConfigMaps(m.tpb.Name).Delete(ctx, topologyConfigMap, metav1.DeleteOptions{})
+		})
+	}
 
 	log.Infof("Pushing Topology to k8s: %q", m.tpb.Name)
-	for _, n := range m.nodes {
-		t := &topologyv1.Topology{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: n.Name(),
-			},
-			Spec: topologyv1.TopologySpec{},
-		}
-		var links []topologyv1.Link
-		for _, intf := range n.Interfaces {
-			link := topologyv1.Link{
-				LocalIntf: intf.Proto.AInt,
-				LocalIP:   "",
-				PeerIntf:  intf.Proto.ZInt,
-				PeerIP:    "",
-				PeerPod:   intf.Proto.ZNode,
-				UID:       intf.UID,
-			}
-			links = append(links, link)
-		}
-		t.Spec.Links = links
+	// All topology resources must exist before any pod is created so meshnet
+	// can wire up the pod's links when it starts.
+	if err := m.forEachNode(func(n *node.Node) error {
+		t := m.topologyCR(n)
 		sT, err := m.tClient.Topology(m.tpb.Name).Create(ctx, t)
 		if err != nil {
 			return err
 		}
+		name := n.Name()
+		undo.push(fmt.Sprintf("topology %q", name), func(ctx context.Context) error {
+			return m.tClient.Topology(m.tpb.Name).Delete(ctx, name, metav1.DeleteOptions{})
+		})
 		log.Infof("Topology:\n%+v\n", sT)
+		return nil
+	}); err != nil {
+		return err
 	}
 	log.Infof("Creating Node Pods")
-	for k, n := range m.nodes {
+	if err := m.forEachNode(func(n *node.Node) error {
+		k := n.Name()
 		if err := n.Configure(ctx); err != nil {
 			return err
 		}
+		undo.push(fmt.Sprintf("config map for node %q", k), n.Delete)
 		if err := n.CreateService(ctx); err != nil {
 			return err
 		}
+		undo.push(fmt.Sprintf("service for node %q", k), n.DeleteService)
 		if err := n.CreatePod(ctx); err != nil {
 			return err
 		}
+		undo.push(fmt.Sprintf("pod for node %q", k), n.DeletePod)
 		log.Infof("Node %q created", k)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readyCheckInterval is the interval at which the readiness of nodes with a
+// ReadyCheck is polled.
+var readyCheckInterval = 5 * time.Second
+
+// PostCreate finishes creating the nodes in names by applying the
+// impairments of their links and running the post create step of their
+// implementation, if any.  Each node is finished as soon as it is ready, so a
+// node which never becomes ready only holds up itself.  A failure does not
+// affect the other nodes or the topology; the errors of all failed nodes are
+// returned.
+func (m *Manager) PostCreate(ctx context.Context, names []string) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, name := range names {
+		n, ok := m.nodes[name]
+		if !ok {
+			return fmt.Errorf("node %q not found in topology", name)
+		}
+		if !n.HasPostCreate() && !impaired(n) {
+			continue
+		}
+		wg.Add(1)
+		go func(n *node.Node) {
+			defer wg.Done()
+			err := m.waitNodeReady(ctx, n)
+			if err == nil {
+				err = setImpairments(ctx, n)
+			}
+			if err == nil {
+				err = n.PostCreate(ctx)
+			}
+			if err != nil {
+				log.Warnf("Post create of node %q failed: %v", n.Name(), err)
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(n)
+	}
+	wg.Wait()
+	return errors.NewAggregate(errs)
+}
+
+// impaired reports whether any link of n has an impairment.
+func impaired(n *node.Node) bool {
+	for _, l := range n.Interfaces {
+		if l.Proto.Impairment != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// setImpairments applies the impairments of the links of n to its end of the
+// links.
+func setImpairments(ctx context.Context, n *node.Node) error {
+	for intf, l := range n.Interfaces {
+		if l.Proto.Impairment == nil {
+			continue
+		}
+		if err := n.SetImpairment(ctx, intf, l.Proto.Impairment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitNodeReady blocks until the pod of n is ready and n passes its
+// ReadyCheck, if it has one.
+func (m *Manager) waitNodeReady(ctx context.Context, n *node.Node) error {
+	s := podStatus(n.Name(), nil)
+	err := wait.PollImmediateUntil(readyCheckInterval, func() (bool, error) {
+		p, err := n.Pod(ctx)
+		switch {
+		case apierrors.IsNotFound(err):
+			p = nil
+		case err != nil:
+			log.Debugf("Failed to get pod of node %q: %v", n.Name(), err)
+			return false, nil
+		}
+		s = podStatus(n.Name(), p)
+		switch {
+		case s.Phase == corev1.PodFailed || s.Phase == corev1.PodSucceeded:
+			return false, fmt.Errorf("node %s", s)
+		case !s.Ready:
+			return false, nil
+		}
+		if n.HasReadyCheck() {
+			ready, reason, err := n.ReadyCheck(ctx)
+			s.Ready, s.Reason = ready, reason
+			return ready, err
+		}
+		return true, nil
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("node %s", s)
+	}
+	return err
+}
+
+// ApplyImpairments makes the impairments of the nodes in names, which must
+// already be running, match their links: impairments are applied to the
+// ends of impaired links and removed from all other ends, e.g. after an
+// impairment was removed from the topology.  Nodes created by Push or Apply
+// get their impairments from PostCreate instead.
+func (m *Manager) ApplyImpairments(ctx context.Context, names []string) error {
+	var errs []error
+	for _, name := range names {
+		n, ok := m.nodes[name]
+		if !ok {
+			return fmt.Errorf("node %q not found in topology", name)
+		}
+		if err := m.waitNodeReady(ctx, n); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for intf, l := range n.Interfaces {
+			err := n.SetImpairment(ctx, intf, l.Proto.Impairment)
+			switch {
+			case err == nil:
+			case l.Proto.Impairment == nil:
+				// Images without a shell or tc cannot have been impaired.
+				log.Warnf("Failed to clear impairment: %v", err)
+			default:
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.NewAggregate(errs)
+}
+
+type linkEnd struct {
+	node *node.Node
+	intf string
+}
+
+// linkEnds returns both ends of the link connected to nodeName:intf.
+func (m *Manager) linkEnds(nodeName, intf string) ([]linkEnd, error) {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found in topology", nodeName)
+	}
+	intf, err := n.LinuxIntf(intf)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := n.Interfaces[intf]
+	if !ok {
+		return nil, fmt.Errorf("interface %s:%s is not connected", nodeName, intf)
+	}
+	peer, ok := m.nodes[l.Proto.ZNode]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found in topology", l.Proto.ZNode)
+	}
+	return []linkEnd{{node: n, intf: intf}, {node: peer, intf: l.Proto.ZInt}}, nil
+}
+
+// SetLinkState sets the admin state of both ends of the link connected to
+// nodeName:intf.
+func (m *Manager) SetLinkState(ctx context.Context, nodeName, intf string, up bool) error {
+	ends, err := m.linkEnds(nodeName, intf)
+	if err != nil {
+		return err
+	}
+	for _, e := range ends {
+		if err := e.node.SetLinkState(ctx, e.intf, up); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLinkImpairment replaces the impairment on both ends of the link
+// connected to nodeName:intf.  A nil impairment clears any existing one.
+func (m *Manager) SetLinkImpairment(ctx context.Context, nodeName, intf string, imp *topopb.Impairment) error {
+	if err := node.ValidateImpairment(imp); err != nil {
+		return err
+	}
+	ends, err := m.linkEnds(nodeName, intf)
+	if err != nil {
+		return err
+	}
+	for _, e := range ends {
+		if err := e.node.SetImpairment(ctx, e.intf, imp); err != nil {
+			return err
+		}
+		e.node.Interfaces[e.intf].Proto.Impairment = imp
 	}
 	return nil
 }
 
+// Capture captures packets on nodeName:intf and writes them in pcap format
+// to w.  See node.Capture for details on count and filter.
+func (m *Manager) Capture(ctx context.Context, nodeName, intf string, count uint32, filter []string, w io.Writer, stderr io.Writer) error {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return fmt.Errorf("node %q not found in topology", nodeName)
+	}
+	intf, err := n.LinuxIntf(intf)
+	if err != nil {
+		return err
+	}
+	return n.Capture(ctx, intf, count, filter, w, stderr)
+}
+
+// Node returns the node nodeName of the loaded topology.
+func (m *Manager) Node(nodeName string) (*node.Node, error) {
+	n, ok := m.nodes[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found in topology", nodeName)
+	}
+	return n, nil
+}
+
+// NodeNames returns the sorted names of all nodes of the loaded topology.
+func (m *Manager) NodeNames() []string {
+	var names []string
+	for name := range m.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResetConfig restores the startup config of the running node nodeName.
+func (m *Manager) ResetConfig(ctx context.Context, nodeName string) error {
+	n, err := m.Node(nodeName)
+	if err != nil {
+		return err
+	}
+	return n.ResetConfig(ctx)
+}
+
+// PushConfig replaces the running config of node nodeName with the config
+// read from r.
+func (m *Manager) PushConfig(ctx context.Context, nodeName string, r io.Reader) error {
+	n, err := m.Node(nodeName)
+	if err != nil {
+		return err
+	}
+	return n.PushConfig(ctx, r)
+}
+
+// GetConfig writes the running config of node nodeName to w.
+func (m *Manager) GetConfig(ctx context.Context, nodeName string, w io.Writer) error {
+	n, err := m.Node(nodeName)
+	if err != nil {
+		return err
+	}
+	return n.GetConfig(ctx, w)
+}
+
 // Delete deletes the topology from k8s.
 func (m *Manager) Delete(ctx context.Context) error {
 	if _, err := m.kClient.CoreV1().Namespaces().Get(ctx, m.tpb.Name, metav1.GetOptions{}); err != nil {
 		return fmt.Errorf("topology %q does not exist in cluster", m.tpb.Name)
 	}
 	// Delete topology pods
-	for _, n := range m.nodes {
+	m.forEachNode(func(n *node.Node) error {
 		// Delete Service for node
 		n.DeleteService(ctx)
 		// Delete config maps for node
 		n.Delete(ctx)
 		// Delete Pod
-		if err := m.kClient.CoreV1().Pods(m.tpb.Name).Delete(ctx, n.Name(), metav1.DeleteOptions{}); err != nil {
+		if err := n.DeletePod(ctx); err != nil {
 			log.Warnf("Error deleting pod %q: %v", n.Name(), err)
 		}
 		// Delete Topology for node
 		if err := m.tClient.Topology(m.tpb.Name).Delete(ctx, n.Name(), metav1.DeleteOptions{}); err != nil {
 			log.Warnf("Error deleting topology %q: %v", n.Name(), err)
 		}
-	}
+		return nil
+	})
 	// Delete namespace
 	prop := metav1.DeletePropagationForeground
 	if err := m.kClient.CoreV1().Namespaces().Delete(ctx, m.tpb.Name, metav1.DeleteOptions{
@@ -252,15 +720,16 @@ func (m *Manager) Delete(ctx context.Context) error {
 	return nil
 }
 
-// Load loads a Topology from fName.
+// Load loads a Topology from fName.  The format of the file is determined by
+// its extension, see FormatFromPath.
 func Load(fName string) (*topopb.Topology, error) {
 	b, err := ioutil.ReadFile(fName)
 	if err != nil {
 		return nil, err
 	}
 	t := &topopb.Topology{}
-	if err := proto.UnmarshalText(string(b), t); err != nil {
-		return nil, err
+	if err := Unmarshal(FormatFromPath(fName), b, t); err != nil {
+		return nil, fmt.Errorf("failed to parse %q as %s: %w", fName, FormatFromPath(fName), err)
 	}
 	return t, nil
 }
@@ -290,6 +759,243 @@ func (m *Manager) Resources(ctx context.Context) (*Resources, error) {
 	return &r, nil
 }
 
+// NodeStatus is the readiness of a single node pod.
+type NodeStatus struct {
+	Name   string
+	Phase  corev1.PodPhase
+	Ready  bool
+	Reason string // Why the node is not ready, empty when Ready.
+}
+
+func (s NodeStatus) String() string {
+	if s.Ready {
+		return fmt.Sprintf("%s: Ready", s.Name)
+	}
+	return fmt.Sprintf("%s: %s (%s)", s.Name, s.Phase, s.Reason)
+}
+
+// podStatus computes the readiness of pod p for node name.
+func podStatus(name string, p *corev1.Pod) NodeStatus {
+	s := NodeStatus{
+		Name:   name,
+		Phase:  corev1.PodUnknown,
+		Reason: "pod not found",
+	}
+	if p == nil {
+		return s
+	}
+	s.Phase = p.Status.Phase
+	s.Reason = ""
+	switch p.Status.Phase {
+	case corev1.PodFailed, corev1.PodSucceeded:
+		s.Reason = fmt.Sprintf("pod terminated: %s %s", p.Status.Reason, p.Status.Message)
+		return s
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			s.Reason = fmt.Sprintf("%s: %s", c.Reason, c.Message)
+			return s
+		}
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, p.Status.InitContainerStatuses...), p.Status.ContainerStatuses...)
+	for _, c := range statuses {
+		switch {
+		case c.State.Waiting != nil:
+			s.Reason = fmt.Sprintf("container %s waiting: %s %s", c.Name, c.State.Waiting.Reason, c.State.Waiting.Message)
+			return s
+		case c.State.Terminated != nil && c.State.Terminated.ExitCode != 0:
+			s.Reason = fmt.Sprintf("container %s terminated: %s (exit code %d)", c.Name, c.State.Terminated.Reason, c.State.Terminated.ExitCode)
+			return s
+		}
+	}
+	if p.Status.Phase != corev1.PodRunning {
+		s.Reason = "pod not running"
+		return s
+	}
+	for _, c := range p.Status.ContainerStatuses {
+		if !c.Ready {
+			s.Reason = fmt.Sprintf("container %s not ready", c.Name)
+			return s
+		}
+	}
+	if len(p.Status.ContainerStatuses) == 0 {
+		s.Reason = "container status not reported"
+		return s
+	}
+	s.Ready = true
+	return s
+}
+
+// Status returns the current status of the pod of every node keyed by node
+// name.
+func (m *Manager) Status(ctx context.Context) (map[string]NodeStatus, error) {
+	pods, err := m.kClient.CoreV1().Pods(m.tpb.Name).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("topo=%s", m.tpb.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]*corev1.Pod{}
+	for i := range pods.Items {
+		found[pods.Items[i].Name] = &pods.Items[i]
+	}
+	status := map[string]NodeStatus{}
+	for name := range m.nodes {
+		status[name] = podStatus(name, found[name])
+	}
+	return status, nil
+}
+
+// Wait blocks until the pods of all nodes are running with all containers
+// ready.  Each change in a node's status is reported to progress if it is
+// non-nil.  If ctx is done before all nodes are ready the returned error
+// includes the reason each remaining node is not ready.
+func (m *Manager) Wait(ctx context.Context, progress func(NodeStatus)) error {
+	status := map[string]NodeStatus{}
+	update := func(name string, p *corev1.Pod) {
+		if _, ok := m.nodes[name]; !ok {
+			return
+		}
+		s := podStatus(name, p)
+		if old, ok := status[name]; ok && old == s {
+			return
+		}
+		status[name] = s
+		if progress != nil {
+			progress(s)
+		}
+	}
+	ready := func() bool {
+		for name := range m.nodes {
+			if !status[name].Ready {
+				return false
+			}
+		}
+		return true
+	}
+	opts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("topo=%s", m.tpb.Name),
+	}
+	for {
+		pods, err := m.kClient.CoreV1().Pods(m.tpb.Name).List(ctx, opts)
+		if err != nil {
+			return m.waitErr(status, err)
+		}
+		found := map[string]*corev1.Pod{}
+		for i := range pods.Items {
+			found[pods.Items[i].Name] = &pods.Items[i]
+		}
+		for name := range m.nodes {
+			update(name, found[name])
+		}
+		if ready() {
+			return nil
+		}
+		wOpts := opts
+		wOpts.ResourceVersion = pods.ResourceVersion
+		w, err := m.kClient.CoreV1().Pods(m.tpb.Name).Watch(ctx, wOpts)
+		if err != nil {
+			return m.waitErr(status, err)
+		}
+		for e := range w.ResultChan() {
+			p, ok := e.Object.(*corev1.Pod)
+			if !ok {
+				continue
+			}
+			switch e.Type {
+			case watch.Deleted:
+				update(p.Name, nil)
+			default:
+				update(p.Name, p)
+			}
+			if ready() {
+				w.Stop()
+				return nil
+			}
+		}
+		w.Stop()
+		// The watch was closed either by ctx or by the server; in the latter
+		// case relist and start a new watch.
+		if ctx.Err() != nil {
+			return m.waitErr(status, ctx.Err())
+		}
+	}
+}
+
+// WaitReady blocks until all nodes are ready.  In addition to the checks of
+// Wait, which include the readiness probes of the node pods, nodes with a
+// ReadyCheck must pass it.  Each change in a node's status is reported to
+// progress if it is non-nil.
+func (m *Manager) WaitReady(ctx context.Context, progress func(NodeStatus)) error {
+	var mu sync.Mutex
+	report := func(s NodeStatus) {
+		if progress == nil {
+			return
+		}
+		mu.Lock()
+		progress(s)
+		mu.Unlock()
+	}
+	if err := m.Wait(ctx, func(s NodeStatus) {
+		if s.Ready && m.nodes[s.Name].HasReadyCheck() {
+			s.Ready = false
+			s.Reason = "waiting for node to finish booting"
+		}
+		report(s)
+	}); err != nil {
+		return err
+	}
+	// Nodes passed Wait, so only those with a ReadyCheck may not be ready.
+	status := map[string]NodeStatus{}
+	for name := range m.nodes {
+		status[name] = NodeStatus{Name: name, Phase: corev1.PodRunning, Ready: true}
+	}
+	err := m.forEachNode(func(n *node.Node) error {
+		if !n.HasReadyCheck() {
+			return nil
+		}
+		return wait.PollImmediateUntil(readyCheckInterval, func() (bool, error) {
+			ready, reason, err := n.ReadyCheck(ctx)
+			s := NodeStatus{Name: n.Name(), Phase: corev1.PodRunning, Ready: ready, Reason: reason}
+			mu.Lock()
+			status[n.Name()] = s
+			mu.Unlock()
+			if ready {
+				report(s)
+			}
+			return ready, err
+		}, ctx.Done())
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	switch {
+	case err == wait.ErrWaitTimeout:
+		return m.waitErr(status, ctx.Err())
+	case err != nil:
+		return m.waitErr(status, err)
+	}
+	return nil
+}
+
+func (m *Manager) waitErr(status map[string]NodeStatus, err error) error {
+	var names []string
+	for name := range m.nodes {
+		if !status[name].Ready {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var reasons []string
+	for _, name := range names {
+		s, ok := status[name]
+		if !ok {
+			s = podStatus(name, nil)
+		}
+		reasons = append(reasons, s.String())
+	}
+	return fmt.Errorf("topology %q not ready: %w; not ready nodes: %s", m.tpb.Name, err, strings.Join(reasons, "; "))
+}
+
 var (
 	muPort   sync.Mutex
 	nextPort uint32 = 30001
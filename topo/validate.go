@@ -0,0 +1,270 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	topopb "github.com/h-fam/kne/proto/topo"
+	"github.com/h-fam/kne/topo/node"
+)
+
+// ValidationError is a single problem found in a topology.
+type ValidationError struct {
+	File string   // Topology file, empty if not validated from a file.
+	Pos  Position // Position in File, zero if unknown.
+	Path string   // Field path, e.g. links[2].a_node.
+	Msg  string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		b.WriteString(e.File)
+		b.WriteString(":")
+		if e.Pos.Line != 0 {
+			b.WriteString(e.Pos.String())
+			b.WriteString(":")
+		}
+		b.WriteString(" ")
+	}
+	if e.Path != "" {
+		b.WriteString(e.Path)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Msg)
+	return b.String()
+}
+
+type validator struct {
+	errs []*ValidationError
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Path: path,
+		Msg:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate checks t for mistakes without contacting a cluster.  All problems
+// found are returned.  Config files are resolved relative to the current
+// directory, as they are when the topology is created.
+func Validate(t *topopb.Topology) []*ValidationError {
+	v := &validator{}
+	if t.Name == "" {
+		v.errorf("name", "topology name must be set")
+	} else {
+		for _, msg := range validation.IsDNS1123Label(t.Name) {
+			v.errorf("name", "invalid topology name %q: %s", t.Name, msg)
+		}
+	}
+	if _, err := newAddressPools(t.GetAddressing()); err != nil {
+		v.errorf("addressing", "%v", err)
+	}
+	nodes := map[string]int{}
+	mappers := map[string]func(string) (string, error){}
+	nodePorts := map[uint32]string{}
+	for i, n := range t.Nodes {
+		path := fmt.Sprintf("nodes[%d]", i)
+		switch {
+		case n.Name == "":
+			v.errorf(path+".name", "node name must be set")
+		default:
+			for _, msg := range validation.IsDNS1123Label(n.Name) {
+				v.errorf(path+".name", "invalid node name %q: %s", n.Name, msg)
+			}
+			if j, ok := nodes[n.Name]; ok {
+				v.errorf(path+".name", "duplicate node name %q, first defined in nodes[%d]", n.Name, j)
+				break
+			}
+			nodes[n.Name] = i
+			mapper, err := node.IntfMapperFor(n)
+			if err != nil {
+				v.errorf(path+".type", "%v", err)
+				break
+			}
+			mappers[n.Name] = mapper
+		}
+		var ports []int
+		for k := range n.Services {
+			ports = append(ports, int(k))
+		}
+		sort.Ints(ports)
+		for _, k := range ports {
+			s := n.Services[uint32(k)]
+			if s.GetOutside() == 0 {
+				continue
+			}
+			if other, ok := nodePorts[s.Outside]; ok {
+				v.errorf(path+".services", "node port %d for service %d already used by node %q", s.Outside, k, other)
+				continue
+			}
+			nodePorts[s.Outside] = n.Name
+		}
+		var keys []string
+		for k := range n.Constraints {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, err := resource.ParseQuantity(n.Constraints[k]); err != nil {
+				v.errorf(path+".constraints", "invalid %s constraint %q: %v", k, n.Constraints[k], err)
+			}
+		}
+		if f := n.GetConfig().GetFile(); f != "" {
+			if _, err := os.Stat(f); err != nil {
+				v.errorf(path+".config.file", "config file: %v", err)
+			}
+		}
+		if n.GetConfig().GetTemplate() {
+			var b []byte
+			if f := n.Config.GetFile(); f != "" {
+				b, _ = ioutil.ReadFile(f)
+			} else {
+				b = n.Config.GetData()
+			}
+			if _, err := node.ParseTemplate(n.Config.ConfigFile, b); err != nil {
+				v.errorf(path+".config.template", "%v", err)
+			}
+		}
+		for _, a := range []struct {
+			field, addr string
+			bits        int
+		}{
+			{"loopback_ipv4", n.LoopbackIpv4, 32},
+			{"loopback_ipv6", n.LoopbackIpv6, 128},
+		} {
+			if a.addr == "" {
+				continue
+			}
+			if _, _, err := parseCIDR(a.addr, a.bits); err != nil {
+				v.errorf(path+"."+a.field, "%v", err)
+			}
+		}
+		var files []string
+		for k := range n.GetConfig().GetFiles() {
+			files = append(files, k)
+		}
+		sort.Strings(files)
+		for _, k := range files {
+			f := n.Config.Files[k]
+			if f.GetContents() == nil {
+				v.errorf(path+".config.files", "config file %q has no contents", k)
+				continue
+			}
+			if p := f.GetPath(); p != "" {
+				if _, err := os.Stat(p); err != nil {
+					v.errorf(path+".config.files", "config file %q: %v", k, err)
+				}
+			}
+		}
+	}
+	intfs := map[string]int{}
+	for i, l := range t.Links {
+		path := fmt.Sprintf("links[%d]", i)
+		for _, end := range []struct {
+			field, node, intf string
+		}{
+			{"a", l.ANode, l.AInt},
+			{"z", l.ZNode, l.ZInt},
+		} {
+			if end.node == "" {
+				v.errorf(path+"."+end.field+"_node", "link endpoint node must be set")
+			} else if _, ok := nodes[end.node]; !ok {
+				v.errorf(path+"."+end.field+"_node", "node %q does not exist", end.node)
+			}
+			if end.intf == "" {
+				v.errorf(path+"."+end.field+"_int", "link endpoint interface must be set")
+				continue
+			}
+			// Interfaces are compared by the Linux interface they map to
+			// since e.g. ethernet-1/1 and e1-1 are the same SR Linux port.
+			intf := end.intf
+			if mapper, ok := mappers[end.node]; ok {
+				var err error
+				if intf, err = mapper(end.intf); err != nil {
+					v.errorf(path+"."+end.field+"_int", "%v", err)
+					continue
+				}
+			}
+			if intf == "eth0" {
+				v.errorf(path+"."+end.field+"_int", "eth0 is reserved for the pod network")
+			}
+			key := end.node + ":" + intf
+			if j, ok := intfs[key]; ok {
+				v.errorf(path+"."+end.field+"_int", "interface %s:%s already connected by links[%d]", end.node, end.intf, j)
+			} else {
+				intfs[key] = i
+			}
+		}
+		for _, a := range []struct {
+			family   string
+			aIP, zIP string
+			bits     int
+		}{
+			{"ipv4", l.AIpv4, l.ZIpv4, 32},
+			{"ipv6", l.AIpv6, l.ZIpv6, 128},
+		} {
+			if (a.aIP == "") != (a.zIP == "") {
+				v.errorf(path, "a_%s and z_%s must be set together", a.family, a.family)
+			}
+			for _, end := range []struct{ field, addr string }{{"a_", a.aIP}, {"z_", a.zIP}} {
+				if end.addr == "" {
+					continue
+				}
+				if _, _, err := parseCIDR(end.addr, a.bits); err != nil {
+					v.errorf(path+"."+end.field+a.family, "%v", err)
+				}
+			}
+		}
+		if l.ANode != "" && l.ANode == l.ZNode {
+			v.errorf(path, "link connects node %q to itself", l.ANode)
+		}
+		if err := node.ValidateImpairment(l.Impairment); err != nil {
+			v.errorf(path+".impairment", "%v", err)
+		}
+	}
+	return v.errs
+}
+
+// ValidateFile loads the topology in fName and validates it.  The returned
+// errors include their position in fName where it can be determined.  A
+// non-nil error is returned if the file cannot be read or parsed.
+func ValidateFile(fName string) ([]*ValidationError, error) {
+	b, err := ioutil.ReadFile(fName)
+	if err != nil {
+		return nil, err
+	}
+	f := FormatFromPath(fName)
+	t := &topopb.Topology{}
+	if err := Unmarshal(f, b, t); err != nil {
+		return nil, fmt.Errorf("failed to parse %q as %s: %w", fName, f, err)
+	}
+	errs := Validate(t)
+	pos := filePositions(f, b)
+	for _, e := range errs {
+		e.File = fName
+		e.Pos, _ = pos.lookup(e.Path)
+	}
+	return errs, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topo
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateFile(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		want []string // Errors without the file name.
+	}{{
+		desc: "valid",
+		in: `name: "t"
+nodes: { name: "r1" type: Host }
+nodes: { name: "r2" type: Host }
+links: { a_node: "r1" a_int: "eth1" z_node: "r2" z_int: "eth1" }
+`,
+	}, {
+		desc: "missing names",
+		in: `nodes: { type: Host }
+links: { a_int: "eth1" z_node: "r2" }
+`,
+		want: []string{
+			"name: topology name must be set",
+			"1:1: nodes[0].name: node name must be set",
+			"2:1: links[0].a_node: link endpoint node must be set",
+			`2:24: links[0].z_node: node "r2" does not exist`,
+			"2:1: links[0].z_int: link endpoint interface must be set",
+		},
+	}, {
+		desc: "duplicate nodes",
+		in: `name: "t"
+nodes: { name: "r1" type: Host }
+nodes: { name: "r1" type: Host }
+`,
+		want: []string{
+			`3:10: nodes[1].name: duplicate node name "r1", first defined in nodes[0]`,
+		},
+	}, {
+		desc: "interfaces",
+		in: `name: "t"
+nodes: { name: "r1" type: Host }
+nodes: { name: "r2" type: Host }
+links: { a_node: "r1" a_int: "eth1" z_node: "r2" z_int: "eth0" }
+links: { a_node: "r1" a_int: "eth1" z_node: "r1" z_int: "eth2" }
+`,
+		want: []string{
+			"4:50: links[0].z_int: eth0 is reserved for the pod network",
+			"5:23: links[1].a_int: interface r1:eth1 already connected by links[0]",
+			`5:1: links[1]: link connects node "r1" to itself`,
+		},
+	}, {
+		desc: "mapped interfaces",
+		in: `name: "t"
+nodes: { name: "r1" type: NokiaSRL }
+nodes: { name: "r2" type: Host }
+links: { a_node: "r1" a_int: "e1-1" z_node: "r2" z_int: "eth1" }
+links: { a_node: "r1" a_int: "ethernet-1/1" z_node: "r2" z_int: "eth2" }
+links: { a_node: "r1" a_int: "bogus" z_node: "r2" z_int: "eth3" }
+`,
+		want: []string{
+			"5:23: links[1].a_int: interface r1:ethernet-1/1 already connected by links[0]",
+			`6:23: links[2].a_int: node "r1": invalid SR Linux interface "bogus"`,
+		},
+	}, {
+		desc: "impairments",
+		in: `name: "t"
+nodes: { name: "r1" type: Host }
+nodes: { name: "r2" type: Host }
+links: {
+  a_node: "r1" a_int: "eth1" z_node: "r2" z_int: "eth1"
+  impairment: { loss: 150 }
+}
+links: {
+  a_node: "r1" a_int: "eth2" z_node: "r2" z_int: "eth2"
+  impairment: { jitter_ms: 10 }
+}
+`,
+		want: []string{
+			"6:3: links[0].impairment: invalid impairment: loss 150% must be between 0 and 100",
+			"10:3: links[1].impairment: invalid impairment: jitter requires delay",
+		},
+	}, {
+		desc: "addresses",
+		in: `name: "t"
+addressing: { link_ipv4: "10.0.0.0/33" }
+nodes: { name: "r1" type: Host loopback_ipv6: "10.0.0.1/32" }
+nodes: { name: "r2" type: Host }
+links: {
+  a_node: "r1" a_int: "eth1" z_node: "r2" z_int: "eth1"
+  a_ipv4: "10.0.0.0/31"
+}
+`,
+		want: []string{
+			"2:1: addressing: invalid link_ipv4 pool: ",
+			`3:32: nodes[0].loopback_ipv6: "10.0.0.1/32" is not an IPv6 address`,
+			"5:1: links[0]: a_ipv4 and z_ipv4 must be set together",
+		},
+	}, {
+		desc: "node config",
+		in: `name: "t"
+nodes: {
+  name: "r1"
+  type: Host
+  constraints: { key: "cpu" value: "lots" }
+  services: { key: 22 value: { inside: 22 outside: 30022 } }
+  config: { file: "missing.cfg" }
+}
+nodes: {
+  name: "r2"
+  type: Host
+  services: { key: 22 value: { inside: 22 outside: 30022 } }
+}
+`,
+		want: []string{
+			`5:3: nodes[0].constraints: invalid cpu constraint "lots": `,
+			"7:13: nodes[0].config.file: config file: ",
+			`12:3: nodes[1].services: node port 30022 for service 22 already used by node "r1"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			fName := filepath.Join(t.TempDir(), "topo.pb.txt")
+			if err := ioutil.WriteFile(fName, []byte(tt.in), 0644); err != nil {
+				t.Fatal(err)
+			}
+			errs, err := ValidateFile(fName)
+			if err != nil {
+				t.Fatalf("ValidateFile() failed: %v", err)
+			}
+			var got []string
+			for _, e := range errs {
+				got = append(got, strings.TrimSpace(strings.TrimPrefix(e.Error(), fName+":")))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ValidateFile() failed: got errors\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			// Messages from other packages are only checked up to their
+			// variable part.
+			for i := range got {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("ValidateFile() error %d: got %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}